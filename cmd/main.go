@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -11,14 +12,17 @@ import (
 	"movie-backend/internal/config"
 	"movie-backend/internal/database"
 	"movie-backend/internal/handlers"
+	"movie-backend/internal/middleware"
 	"movie-backend/internal/repository"
 	"movie-backend/internal/routes"
 	"movie-backend/internal/services"
+	"movie-backend/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/joho/godotenv"
 	"github.com/sirupsen/logrus"
 	fiberSwagger "github.com/swaggo/fiber-swagger"
@@ -40,6 +44,16 @@ import (
 // @BasePath /api/v1
 // @schemes http https
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT access token from /auth/login or /auth/refresh, sent as "Bearer <token>". Catalog reads are public; writes need the editor role, and sync, trash purge and /users the admin role.
+
+// @securityDefinitions.apikey APIKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key created by an admin under /api-keys. Keys are limited by their scopes: movies:read, movies:write, sync:run and upload:write.
+
 func main() {
 	// Load environment variables
 	loadEnvFile()
@@ -50,11 +64,17 @@ func main() {
 	// Setup logger
 	log := setupLogger()
 
-	// Validate configuration
+	// Validate configuration. Without a valid auth config anyone could sign
+	// access tokens, so it is the only part that stops the server.
+	if err := cfg.Auth.Validate(); err != nil {
+		log.Fatalf("Invalid auth configuration: %v", err)
+	}
 	if err := cfg.Validate(); err != nil {
 		log.Warnf("Configuration validation warning: %v", err)
 	}
 
+	utils.ConfigureErrorFormat(cfg.Server.ErrorFormat, cfg.Server.ProblemTypeBaseURL)
+
 	// Connect to database
 	db, err := database.Connect(cfg.Database)
 	if err != nil {
@@ -69,7 +89,12 @@ func main() {
 	movieRepo := repository.NewMovieRepository(db)
 	genreRepo := repository.NewGenreRepository(db)
 	langRepo := repository.NewLanguageRepository(db)
-	movieService := services.NewMovieService(movieRepo, genreRepo, langRepo, cfg, log)
+	collectionRepo := repository.NewCollectionRepository(db)
+	tagRepo := repository.NewTagRepository(db)
+	providerRepo := repository.NewWatchProviderRepository(db)
+	userMovieRepo := repository.NewUserMovieRepository(db)
+	reviewRepo := repository.NewReviewRepository(db)
+	movieService := services.NewMovieService(movieRepo, genreRepo, langRepo, collectionRepo, tagRepo, providerRepo, userMovieRepo, reviewRepo, cfg, log)
 	movieHandler := handlers.NewMovieHandler(movieService, log)
 
 	minioService, err := services.NewMinIOService(&cfg.MinIO, log)
@@ -81,13 +106,49 @@ func main() {
 		ms.SetMinIOService(minioService)
 	}
 
+	cache, err := services.NewCache(&cfg.Cache, log)
+	if err != nil {
+		log.Warnf("Failed to initialize %s cache, falling back to in-memory cache: %v", cfg.Cache.Backend, err)
+		cache = services.NewMemoryCache(cfg.Cache.MaxEntries)
+	}
+	if ms, ok := movieService.(interface{ SetCache(services.Cache) }); ok {
+		ms.SetCache(cache)
+	}
+
 	uploadHandler := handlers.NewUploadHandler(minioService, log)
 
+	userRepo := repository.NewUserRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	authService, err := services.NewAuthService(userRepo, apiKeyRepo, &cfg.Auth, log)
+	if err != nil {
+		log.Fatalf("Failed to create auth service: %v", err)
+	}
+	authHandler := handlers.NewAuthHandler(authService, log)
+	if err := authService.SeedAdmin(context.Background()); err != nil {
+		log.Errorf("Failed to seed admin account: %v", err)
+	}
+	go services.StartRefreshTokenCleanup(context.Background(), authService, time.Hour, log)
+
+	go services.StartTrashPurge(context.Background(), movieService, cfg.Server.TrashPurgeInterval, log)
+
+	rateLimiter, err := services.NewRateLimiter(&cfg.RateLimit, &cfg.Cache, log)
+	if err != nil {
+		log.Warnf("Failed to initialize %s rate limiter, falling back to in-memory counters: %v", cfg.RateLimit.Backend, err)
+		rateLimiter = services.NewMemoryRateLimiter()
+	}
+	rateLimitMiddleware := middleware.RateLimit(rateLimiter, cfg.RateLimit, "/api/v1/sync", log)
+	authFailureLimitMiddleware := middleware.RateLimitAuthFailures(rateLimiter, cfg.RateLimit, log)
+
+	idempotencyRepo := repository.NewIdempotencyRepository(db)
+	idempotencyMiddleware := middleware.Idempotency(idempotencyRepo, cfg.Server.IdempotencyTTL, log)
+	go middleware.StartIdempotencyCleanup(context.Background(), idempotencyRepo, time.Hour, log)
+
 	app := fiber.New(fiber.Config{
 		AppName:               "Movie Backend API",
 		ReadTimeout:           cfg.Server.ReadTimeout,
 		WriteTimeout:          cfg.Server.WriteTimeout,
 		IdleTimeout:           120 * time.Second,
+		ProxyHeader:           cfg.Server.ProxyHeader,
 		DisableStartupMessage: false,
 		ErrorHandler:          customErrorHandler(log),
 	})
@@ -100,7 +161,7 @@ func main() {
 	app.Get("/swagger/*", fiberSwagger.WrapHandler)
 
 	// Setup API routes
-	routes.Setup(app, movieHandler, uploadHandler)
+	routes.Setup(app, movieHandler, uploadHandler, authHandler, authFailureLimitMiddleware, middleware.Authenticate(authService), rateLimitMiddleware, idempotencyMiddleware)
 
 	// Graceful shutdown
 	go gracefulShutdown(app, log)
@@ -131,9 +192,12 @@ func setupMiddleware(app *fiber.App) {
 		EnableStackTrace: true,
 	}))
 
+	// Request ID middleware, used for tracing and problem details
+	app.Use(requestid.New())
+
 	// Logger middleware
 	app.Use(logger.New(logger.Config{
-		Format:     "${time} | ${status} | ${latency} | ${ip} | ${method} | ${path} | ${error}\n",
+		Format:     "${time} | ${status} | ${latency} | ${ip} | ${method} | ${path} | ${locals:requestid} | ${error}\n",
 		TimeFormat: "15:04:05",
 		TimeZone:   "Local",
 	}))
@@ -141,7 +205,8 @@ func setupMiddleware(app *fiber.App) {
 	// CORS middleware
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     "*",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Request-ID",
+		AllowHeaders:     "Origin, Content-Type, Accept, Accept-Language, Authorization, X-API-Key, X-Request-ID, If-None-Match, If-Modified-Since, If-Match, Idempotency-Key",
+		ExposeHeaders:    "ETag, Last-Modified, X-Request-ID, Idempotent-Replayed, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After",
 		AllowMethods:     "GET, POST, PUT, DELETE, OPTIONS, PATCH",
 		AllowCredentials: false,
 		MaxAge:           86400, // 24 hours
@@ -174,11 +239,16 @@ func customErrorHandler(log *logrus.Logger) fiber.ErrorHandler {
 		}
 
 		log.WithError(err).WithFields(logrus.Fields{
-			"method": c.Method(),
-			"path":   c.Path(),
-			"status": code,
+			"method":     c.Method(),
+			"path":       c.Path(),
+			"status":     code,
+			"request_id": utils.RequestID(c),
 		}).Error("Request error")
 
+		if utils.WantsProblemDetails(c) {
+			return utils.ProblemResponse(c, code, err.Error(), nil)
+		}
+
 		return c.Status(code).JSON(fiber.Map{
 			"status":  "error",
 			"code":    code,
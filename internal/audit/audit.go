@@ -0,0 +1,57 @@
+// Package audit carries who made a change, and through which channel, from the
+// HTTP layer down to the services that record movie revisions.
+package audit
+
+import "context"
+
+// Sources of a change
+const (
+	SourceAPI    = "api"
+	SourceSync   = "sync"
+	SourceImport = "import"
+)
+
+// AnonymousActor is recorded for requests without an authenticated user
+const AnonymousActor = "anonymous"
+
+// LocalsActorKey is the fiber.Ctx Locals key holding the name of the
+// authenticated user, set by the authentication middleware
+const LocalsActorKey = "audit_actor"
+
+// LocalsActorRoleKey is the fiber.Ctx Locals key holding the role of the
+// authenticated user, set by the authentication middleware
+const LocalsActorRoleKey = "audit_actor_role"
+
+// Actor describes who made a change
+type Actor struct {
+	Name      string
+	Role      string // empty for anonymous actors
+	Source    string
+	RequestID string
+}
+
+type actorKey struct{}
+
+// WithActor returns a context that carries the actor
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// WithSource returns a context whose actor has the given source
+func WithSource(ctx context.Context, source string) context.Context {
+	actor := ActorFrom(ctx)
+	actor.Source = source
+	return WithActor(ctx, actor)
+}
+
+// ActorFrom returns the actor carried by ctx, or an anonymous API actor
+func ActorFrom(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	if actor.Name == "" {
+		actor.Name = AnonymousActor
+	}
+	if actor.Source == "" {
+		actor.Source = SourceAPI
+	}
+	return actor
+}
@@ -4,20 +4,30 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	TMDB     TMDBConfig
-	MinIO    MinIOConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	TMDB      TMDBConfig
+	MinIO     MinIOConfig
+	Cache     CacheConfig
+	Auth      AuthConfig
+	RateLimit RateLimitConfig
 }
 
 type ServerConfig struct {
-	Port         string
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
+	Port               string
+	ReadTimeout        time.Duration
+	WriteTimeout       time.Duration
+	ErrorFormat        string // "standard" (negotiated) or "problem" (always RFC 7807)
+	ProblemTypeBaseURL string
+	IdempotencyTTL     time.Duration
+	TrashRetention     time.Duration // how long deleted movies stay restorable
+	TrashPurgeInterval time.Duration
+	ProxyHeader        string // header with the client IP, e.g. X-Forwarded-For; only set behind a proxy that overwrites it
 }
 
 type DatabaseConfig struct {
@@ -37,6 +47,7 @@ type TMDBConfig struct {
 	APIKey      string
 	BaseURL     string
 	HTTPTimeout time.Duration
+	Locales     []string // translations synced besides English, e.g. "id-ID"
 }
 
 type MinIOConfig struct {
@@ -49,12 +60,68 @@ type MinIOConfig struct {
 	PublicURL       string
 }
 
+type CacheConfig struct {
+	Backend       string // "memory" (default) or "redis"
+	TTL           time.Duration
+	MaxEntries    int
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+	KeyPrefix     string
+}
+
+type AuthConfig struct {
+	JWTSecret       string
+	JWTIssuer       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	AdminEmail      string // account created as admin on startup, if it doesn't exist
+	AdminPassword   string
+}
+
+// MinJWTSecretLength is the shortest JWT_SECRET accepted
+const MinJWTSecretLength = 32
+
+// Validate checks the settings the API can't be secured without. An empty
+// secret would let anyone sign access tokens.
+func (c *AuthConfig) Validate() error {
+	if len(c.JWTSecret) < MinJWTSecretLength {
+		return fmt.Errorf("JWT_SECRET is required and must be at least %d characters", MinJWTSecretLength)
+	}
+	return nil
+}
+
+// RateLimit allows Requests requests per Window; 0 requests disables it
+type RateLimit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// RateLimitConfig holds the per-client limits of each route group. Clients
+// are counted by API key, user or IP.
+type RateLimitConfig struct {
+	Enabled bool
+	Backend string // "memory" or "redis"; defaults to the cache backend
+	Read    RateLimit
+	Write   RateLimit
+	Sync    RateLimit // POST /sync, which spends TMDB quota
+	// AuthFailure counts requests with an invalid access token or API key
+	// per IP, before the caller is known
+	AuthFailure RateLimit
+}
+
 func Load() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Port:         getEnvOrDefault("SERVER_PORT", "8010"),
-			ReadTimeout:  getDurationOrDefault("SERVER_READ_TIMEOUT", 30*time.Second),
-			WriteTimeout: getDurationOrDefault("SERVER_WRITE_TIMEOUT", 30*time.Second),
+			Port:               getEnvOrDefault("SERVER_PORT", "8010"),
+			ReadTimeout:        getDurationOrDefault("SERVER_READ_TIMEOUT", 30*time.Second),
+			WriteTimeout:       getDurationOrDefault("SERVER_WRITE_TIMEOUT", 30*time.Second),
+			ErrorFormat:        getEnvOrDefault("API_ERROR_FORMAT", "standard"),
+			ProblemTypeBaseURL: getEnvOrDefault("API_PROBLEM_TYPE_BASE_URL", ""),
+			IdempotencyTTL:     getDurationOrDefault("IDEMPOTENCY_TTL", 24*time.Hour),
+			TrashRetention:     getDurationOrDefault("TRASH_RETENTION", 30*24*time.Hour),
+			TrashPurgeInterval: getDurationOrDefault("TRASH_PURGE_INTERVAL", time.Hour),
+			ProxyHeader:        getEnvOrDefault("SERVER_PROXY_HEADER", ""),
 		},
 		Database: DatabaseConfig{
 			Host:            getEnvOrDefault("DB_HOST", "localhost"),
@@ -72,6 +139,7 @@ func Load() *Config {
 			APIKey:      os.Getenv("TMDB_API_KEY"),
 			BaseURL:     getEnvOrDefault("TMDB_BASE_URL", "https://api.themoviedb.org/3"),
 			HTTPTimeout: getDurationOrDefault("TMDB_HTTP_TIMEOUT", 30*time.Second),
+			Locales:     getListOrDefault("TMDB_LOCALES", []string{"id-ID"}),
 		},
 		MinIO: MinIOConfig{
 			Endpoint:        getEnvOrDefault("AWS_ENDPOINT", "storage.bpdabujapijabar.or.id"),
@@ -82,6 +150,43 @@ func Load() *Config {
 			UseSSL:          getBoolOrDefault("AWS_USE_SSL", true), // Use SSL by default for HTTPS
 			PublicURL:       getEnvOrDefault("AWS_URL", "https://storage.bpdabujapijabar.or.id/movies"),
 		},
+		Cache: CacheConfig{
+			Backend:       getEnvOrDefault("CACHE_BACKEND", "memory"),
+			TTL:           getDurationOrDefault("CACHE_TTL", 5*time.Minute),
+			MaxEntries:    getIntOrDefault("CACHE_MAX_ENTRIES", 1000),
+			RedisAddr:     getEnvOrDefault("REDIS_ADDR", "localhost:6379"),
+			RedisPassword: getEnvOrDefault("REDIS_PASSWORD", ""),
+			RedisDB:       getIntOrDefault("REDIS_DB", 0),
+			KeyPrefix:     getEnvOrDefault("CACHE_KEY_PREFIX", "movie-backend:"),
+		},
+		Auth: AuthConfig{
+			JWTSecret:       os.Getenv("JWT_SECRET"),
+			JWTIssuer:       getEnvOrDefault("JWT_ISSUER", "movie-backend"),
+			AccessTokenTTL:  getDurationOrDefault("JWT_ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL: getDurationOrDefault("JWT_REFRESH_TOKEN_TTL", 30*24*time.Hour),
+			AdminEmail:      os.Getenv("ADMIN_EMAIL"),
+			AdminPassword:   os.Getenv("ADMIN_PASSWORD"),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: getBoolOrDefault("RATE_LIMIT_ENABLED", true),
+			Backend: getEnvOrDefault("RATE_LIMIT_BACKEND", getEnvOrDefault("CACHE_BACKEND", "memory")),
+			Read: RateLimit{
+				Requests: getIntOrDefault("RATE_LIMIT_READ_REQUESTS", 300),
+				Window:   getDurationOrDefault("RATE_LIMIT_READ_WINDOW", time.Minute),
+			},
+			Write: RateLimit{
+				Requests: getIntOrDefault("RATE_LIMIT_WRITE_REQUESTS", 60),
+				Window:   getDurationOrDefault("RATE_LIMIT_WRITE_WINDOW", time.Minute),
+			},
+			Sync: RateLimit{
+				Requests: getIntOrDefault("RATE_LIMIT_SYNC_REQUESTS", 5),
+				Window:   getDurationOrDefault("RATE_LIMIT_SYNC_WINDOW", time.Hour),
+			},
+			AuthFailure: RateLimit{
+				Requests: getIntOrDefault("RATE_LIMIT_AUTH_FAILURE_REQUESTS", 20),
+				Window:   getDurationOrDefault("RATE_LIMIT_AUTH_FAILURE_WINDOW", 15*time.Minute),
+			},
+		},
 	}
 }
 
@@ -114,6 +219,14 @@ func (c *Config) Validate() error {
 	if c.MinIO.Endpoint == "" {
 		return fmt.Errorf("AWS_ENDPOINT is required for MinIO")
 	}
+	if err := c.Auth.Validate(); err != nil {
+		return err
+	}
+	for name, limit := range map[string]RateLimit{"READ": c.RateLimit.Read, "WRITE": c.RateLimit.Write, "SYNC": c.RateLimit.Sync, "AUTH_FAILURE": c.RateLimit.AuthFailure} {
+		if limit.Requests > 0 && limit.Window <= 0 {
+			return fmt.Errorf("RATE_LIMIT_%s_WINDOW must be positive", name)
+		}
+	}
 	return nil
 }
 
@@ -150,3 +263,18 @@ func getBoolOrDefault(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+func getListOrDefault(key string, defaultValue []string) []string {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,7 +1,13 @@
 package constants
 
+import "time"
+
 // Application constants
 const (
 	DefaultPageSize = 20
 	MaxPageSize     = 100
+	MaxImportRows   = 5000
+
+	// ExportTimeout bounds a single streaming catalog export
+	ExportTimeout = 10 * time.Minute
 )
@@ -29,6 +29,7 @@ func Connect(cfg config.DatabaseConfig) (*Database, error) {
 			return time.Now().UTC()
 		},
 		DisableForeignKeyConstraintWhenMigrating: true,
+		TranslateError:                           true, // unique violations become gorm.ErrDuplicatedKey
 		PrepareStmt:                              true, // Enable prepared statement cache
 	}
 
@@ -83,6 +84,14 @@ func (d *Database) WithTimeout() (*gorm.DB, context.CancelFunc) {
 	return d.DB.WithContext(ctx), cancel
 }
 
+// Transaction runs fn inside a database transaction. The *Database passed to
+// fn is bound to the transaction and shares the parent's configuration.
+func (d *Database) Transaction(ctx context.Context, fn func(tx *Database) error) error {
+	return d.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&Database{DB: tx, config: d.config})
+	})
+}
+
 func (d *Database) GetQueryTimeout() time.Duration {
 	return d.config.QueryTimeout
 }
@@ -112,16 +121,49 @@ func autoMigrate(db *gorm.DB) error {
 
 	err := db.AutoMigrate(
 		&models.Movie{},
+		&models.MovieTranslation{},
+		&models.MovieVideo{},
+		&models.MovieReleaseDate{},
+		&models.WatchProvider{},
+		&models.MovieWatchProvider{},
+		&models.User{},
+		&models.RefreshToken{},
+		&models.APIKey{},
+		&models.UserMovie{},
+		&models.MovieReview{},
+		&models.MovieRevision{},
+		&models.MovieFieldLock{},
 		&models.SyncLog{},
 		&models.Genre{},
+		&models.GenreAlias{},
 		&models.Language{},
+		&models.LanguageTranslation{},
 		&models.MovieGenre{},
+		&models.Collection{},
+		&models.Tag{},
+		&models.Keyword{},
+		&models.CatalogState{},
+		&models.IdempotencyKey{},
 	)
 
 	if err != nil {
 		return err
 	}
 
+	// Tag names used to be unique case-sensitively; idx_tags_name_lower replaces it
+	if db.Migrator().HasIndex(&models.Tag{}, "idx_tags_name") {
+		if err := db.Migrator().DropIndex(&models.Tag{}, "idx_tags_name"); err != nil {
+			return err
+		}
+	}
+
+	// Idempotency keys used to be unique across all callers; idx_idempotency_keys_actor_key replaces it
+	if db.Migrator().HasIndex(&models.IdempotencyKey{}, "idx_idempotency_keys_idempotency_key") {
+		if err := db.Migrator().DropIndex(&models.IdempotencyKey{}, "idx_idempotency_keys_idempotency_key"); err != nil {
+			return err
+		}
+	}
+
 	logrus.Info("Auto migration completed successfully")
 	return nil
 }
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"strconv"
+
+	"movie-backend/internal/middleware"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetAPIKeys godoc
+// @Summary Get API keys
+// @Description Get the API keys, newest first. Keys themselves are never returned after creation, only their prefix.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.StandardResponse "List of API keys"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the admin role"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /api-keys [get]
+func (h *AuthHandler) GetAPIKeys(c *fiber.Ctx) error {
+	keys, err := h.service.GetAPIKeys(c.Context())
+	if err != nil {
+		return h.authErrorResponse(c, err, "Failed to retrieve API keys")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "API keys retrieved successfully", keys)
+}
+
+// CreateAPIKey godoc
+// @Summary Create an API key
+// @Description Create an API key with scopes (movies:read, movies:write, sync:run, upload:write) and an optional expiry. Clients send it in the X-API-Key header. The key is only returned in this response.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param key body CreateAPIKeyRequest true "API key request object"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 201 {object} utils.StandardResponse "API key created successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid name, scopes or expiry"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the admin role"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /api-keys [post]
+func (h *AuthHandler) CreateAPIKey(c *fiber.Ctx) error {
+	var req CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	created, err := h.service.CreateAPIKey(c.Context(), middleware.IdentityFrom(c).UserID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		return h.authErrorResponse(c, err, "Failed to create API key")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "API key created successfully", created)
+}
+
+// DeleteAPIKey godoc
+// @Summary Delete an API key
+// @Description Revoke an API key. Requests sending it are rejected right away.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "API key ID"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "API key deleted successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid API key ID"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the admin role"
+// @Failure 404 {object} utils.StandardResponse "API key not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /api-keys/{id} [delete]
+func (h *AuthHandler) DeleteAPIKey(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid API key ID")
+	}
+
+	if err := h.service.DeleteAPIKey(c.Context(), uint(id)); err != nil {
+		return h.authErrorResponse(c, err, "Failed to delete API key")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "API key deleted successfully", nil)
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import "time"
+
+type RegisterRequest struct {
+	Email    string `json:"email" example:"editor@example.com"`
+	Password string `json:"password" example:"correct-horse-battery"`
+	Name     string `json:"name" example:"Jane Editor"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" example:"editor@example.com"`
+	Password string `json:"password" example:"correct-horse-battery"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" example:"q3Jd9X0wV1nH2kS8b7yZ4uT6rE5cA1mP0oL9iK8jH7g"`
+}
+
+type UserRoleRequest struct {
+	Role string `json:"role" example:"editor"` // viewer, editor or admin
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" example:"nightly-sync"`
+	Scopes    []string   `json:"scopes" example:"movies:read,sync:run"`
+	ExpiresAt *time.Time `json:"expires_at" example:"2027-01-01T00:00:00Z"` // omit for a key that doesn't expire
+}
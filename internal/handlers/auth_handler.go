@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"errors"
+
+	"movie-backend/internal/middleware"
+	"movie-backend/internal/services"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type AuthHandler struct {
+	service services.AuthService
+	logger  *logrus.Logger
+}
+
+func NewAuthHandler(service services.AuthService, logger *logrus.Logger) *AuthHandler {
+	return &AuthHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Register godoc
+// @Summary Register a user
+// @Description Create an account. Passwords must be 8 to 72 bytes long and are stored as bcrypt hashes.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param user body RegisterRequest true "Registration request object"
+// @Success 201 {object} utils.StandardResponse "User registered successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid email or password"
+// @Failure 409 {object} utils.StandardResponse "Email already registered"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /auth/register [post]
+func (h *AuthHandler) Register(c *fiber.Ctx) error {
+	var req RegisterRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	user, err := h.service.Register(c.Context(), req.Email, req.Password, req.Name)
+	if err != nil {
+		return h.authErrorResponse(c, err, "Failed to register user")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "User registered successfully", user)
+}
+
+// Login godoc
+// @Summary Log in
+// @Description Exchange email and password for a short-lived JWT access token and a refresh token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body LoginRequest true "Login request object"
+// @Success 200 {object} utils.StandardResponse "Logged in successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid request body"
+// @Failure 401 {object} utils.StandardResponse "Invalid email or password"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *fiber.Ctx) error {
+	var req LoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tokens, err := h.service.Login(c.Context(), req.Email, req.Password)
+	if err != nil {
+		return h.authErrorResponse(c, err, "Failed to log in")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Logged in successfully", tokens)
+}
+
+// RefreshToken godoc
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access token and refresh token. Each refresh token works once; reusing one signs out the login it belongs to.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body RefreshTokenRequest true "Refresh token request object"
+// @Success 200 {object} utils.StandardResponse "Tokens refreshed successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid request body"
+// @Failure 401 {object} utils.StandardResponse "Refresh token is invalid or expired"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
+	var req RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil || req.RefreshToken == "" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "refresh_token is required")
+	}
+
+	tokens, err := h.service.Refresh(c.Context(), req.RefreshToken)
+	if err != nil {
+		return h.authErrorResponse(c, err, "Failed to refresh tokens")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Tokens refreshed successfully", tokens)
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description Revoke the refresh token and every token refreshed from the same login. Access tokens stay valid until they expire.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body RefreshTokenRequest true "Refresh token request object"
+// @Success 200 {object} utils.StandardResponse "Logged out successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid request body"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	var req RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil || req.RefreshToken == "" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "refresh_token is required")
+	}
+
+	if err := h.service.Logout(c.Context(), req.RefreshToken); err != nil {
+		return h.authErrorResponse(c, err, "Failed to log out")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Logged out successfully", nil)
+}
+
+// Me godoc
+// @Summary Get the current user
+// @Description Get the account of the authenticated caller
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.StandardResponse "Current user"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /auth/me [get]
+func (h *AuthHandler) Me(c *fiber.Ctx) error {
+	identity := middleware.IdentityFrom(c)
+	if identity == nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Authentication required")
+	}
+	if identity.APIKey != nil {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, "API keys have no user account")
+	}
+
+	user, err := h.service.GetUser(c.Context(), identity.UserID)
+	if errors.Is(err, services.ErrUserNotFound) {
+		// The account behind a still valid access token was removed
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Authentication required")
+	}
+	if err != nil {
+		return h.authErrorResponse(c, err, "Failed to retrieve user")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "User retrieved successfully", user)
+}
+
+func (h *AuthHandler) authErrorResponse(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidEmail), errors.Is(err, services.ErrPasswordTooShort),
+		errors.Is(err, services.ErrPasswordTooLong):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrEmailTaken):
+		return utils.ErrorResponse(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrInvalidCredentials), errors.Is(err, services.ErrInvalidRefreshToken):
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, err.Error())
+	case errors.Is(err, services.ErrInvalidRole), errors.Is(err, services.ErrAPIKeyNameRequired),
+		errors.Is(err, services.ErrAPIKeyScopes), errors.Is(err, services.ErrAPIKeyExpiry):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrCannotChangeSelf):
+		return utils.ErrorResponse(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrUserNotFound), errors.Is(err, services.ErrAPIKeyNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, err.Error())
+	}
+
+	h.logger.WithError(err).Error(message)
+	return utils.ErrorResponse(c, fiber.StatusInternalServerError, message)
+}
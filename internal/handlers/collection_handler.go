@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"movie-backend/internal/services"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetCollections godoc
+// @Summary Get all collections
+// @Description Get TMDB collections (franchises) with their movie count, average rating and span of release years
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Param search query string false "Search by collection name"
+// @Success 200 {object} utils.StandardResponse "List of collections"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /collections [get]
+func (h *MovieHandler) GetCollections(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	if h.catalogNotModified(c) {
+		return utils.NotModifiedResponse(c)
+	}
+
+	collections, total, err := h.service.GetCollections(c.Context(), c.Query("search", ""), page, limit)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get collections")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve collections")
+	}
+
+	// Same bounds as the service applies
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	meta := utils.CreatePaginationMeta(page, limit, total)
+	return utils.SuccessWithMetaResponse(c, fiber.StatusOK, "Collections retrieved successfully", collections, meta)
+}
+
+// GetCollection godoc
+// @Summary Get a collection
+// @Description Get a collection with its movie count, average rating and span of release years
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Success 200 {object} utils.StandardResponse "Collection details"
+// @Failure 400 {object} utils.StandardResponse "Invalid collection ID"
+// @Failure 404 {object} utils.StandardResponse "Collection not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /collections/{id} [get]
+func (h *MovieHandler) GetCollection(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid collection ID")
+	}
+
+	if h.catalogNotModified(c) {
+		return utils.NotModifiedResponse(c)
+	}
+
+	collection, err := h.service.GetCollection(c.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrCollectionNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Collection not found")
+		}
+		h.logger.WithError(err).WithField("id", id).Error("Failed to get collection")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve collection")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Collection retrieved successfully", collection)
+}
+
+// GetCollectionMovies godoc
+// @Summary Get movies of a collection
+// @Description Get the movies of a collection in release order. Accepts the same pagination, search, sorting and date filters as the movie list
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Param search query string false "Search by title, translated title or overview"
+// @Param sort_by query string false "Sort by field (id, title, release_date, vote_average, user_rating, popularity, created_at, updated_at)" default(release_date)
+// @Param order query string false "Sort order (ASC/DESC)" default(ASC)
+// @Param start_date query string false "Filter by start date (YYYY-MM-DD)"
+// @Param end_date query string false "Filter by end date (YYYY-MM-DD)"
+// @Param region query string false "ISO 3166-1 country; date filters use the release dates in this country"
+// @Param max_certification query string false "Most restrictive age certification in region (e.g. PG-13), requires region"
+// @Param fields query string false "Comma separated fields to return (e.g. id,title,poster_path)"
+// @Param expand query string false "Comma separated relations to load (genres, language, collection, keywords, tags). Keywords and tags are only loaded when listed; the others are loaded when omitted"
+// @Param lang query string false "Locale for title, overview and tagline (e.g. id). Overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred locales for title, overview and tagline; English is the fallback"
+// @Success 200 {object} utils.StandardResponse "List of movies"
+// @Failure 400 {object} utils.StandardResponse "Invalid collection ID, filters, fields or expand"
+// @Failure 404 {object} utils.StandardResponse "Collection not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /collections/{id}/movies [get]
+func (h *MovieHandler) GetCollectionMovies(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid collection ID")
+	}
+
+	filter, err := movieFilterFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+	if c.Query("sort_by") == "" {
+		filter.SortBy = "release_date"
+		filter.Order = c.Query("order", "ASC")
+	}
+	projection, err := movieProjectionFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if h.catalogNotModified(c) {
+		return utils.NotModifiedResponse(c)
+	}
+
+	movies, total, err := h.service.GetCollectionMovies(ctx, uint(id), filter, projection)
+	if err != nil {
+		if errors.Is(err, services.ErrCollectionNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Collection not found")
+		}
+		h.logger.WithError(err).WithField("id", id).Error("Failed to get collection movies")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve movies")
+	}
+
+	if err := h.annotateUserMovies(c, movies); err != nil {
+		h.logger.WithError(err).Error("Failed to load personal movie lists")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve movies")
+	}
+
+	data, err := projectMovies(movies, projection)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to project movies")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve movies")
+	}
+
+	meta := utils.CreatePaginationMeta(filter.Page, filter.Limit, total)
+	return utils.SuccessWithMetaResponse(c, fiber.StatusOK, "Movies retrieved successfully", data, meta)
+}
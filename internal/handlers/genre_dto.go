@@ -0,0 +1,9 @@
+package handlers
+
+type GenreRequest struct {
+	Name string `json:"name" example:"Science Fiction"`
+}
+
+type GenreMergeRequest struct {
+	SourceID uint `json:"source_id" example:"12"`
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"movie-backend/internal/services"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetGenres godoc
+// @Summary Get all genres
+// @Description Get all genres with the number of movies in each genre
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Success 200 {object} utils.StandardResponse "List of genres"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /genres [get]
+func (h *MovieHandler) GetGenres(c *fiber.Ctx) error {
+	genres, err := h.service.GetGenres(c.Context())
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get genres")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve genres")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Genres retrieved successfully", genres)
+}
+
+// GetGenreMovies godoc
+// @Summary Get movies of a genre
+// @Description Get the movies of a genre with the same pagination, search, sorting and date filters as the movie list
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Param search query string false "Search by title, translated title or overview"
+// @Param sort_by query string false "Sort by field (id, title, release_date, vote_average, user_rating, popularity, created_at, updated_at)" default(updated_at)
+// @Param order query string false "Sort order (ASC/DESC)" default(DESC)
+// @Param start_date query string false "Filter by start date (YYYY-MM-DD)"
+// @Param end_date query string false "Filter by end date (YYYY-MM-DD)"
+// @Param region query string false "ISO 3166-1 country; date filters use the release dates in this country"
+// @Param max_certification query string false "Most restrictive age certification in region (e.g. PG-13), requires region"
+// @Param fields query string false "Comma separated fields to return (e.g. id,title,poster_path)"
+// @Param expand query string false "Comma separated relations to load (genres, language, collection, keywords, tags). Keywords and tags are only loaded when listed; the others are loaded when omitted"
+// @Param lang query string false "Locale for title, overview and tagline (e.g. id). Overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred locales for title, overview and tagline; English is the fallback"
+// @Success 200 {object} utils.StandardResponse "List of movies"
+// @Failure 400 {object} utils.StandardResponse "Invalid genre ID, filters, fields or expand"
+// @Failure 404 {object} utils.StandardResponse "Genre not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /genres/{id}/movies [get]
+func (h *MovieHandler) GetGenreMovies(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid genre ID")
+	}
+
+	filter, err := movieFilterFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+	projection, err := movieProjectionFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if h.catalogNotModified(c) {
+		return utils.NotModifiedResponse(c)
+	}
+
+	movies, total, err := h.service.GetGenreMovies(ctx, uint(id), filter, projection)
+	if err != nil {
+		if errors.Is(err, services.ErrGenreNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Genre not found")
+		}
+		h.logger.WithError(err).WithField("id", id).Error("Failed to get genre movies")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve movies")
+	}
+
+	if err := h.annotateUserMovies(c, movies); err != nil {
+		h.logger.WithError(err).Error("Failed to load personal movie lists")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve movies")
+	}
+
+	data, err := projectMovies(movies, projection)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to project movies")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve movies")
+	}
+
+	meta := utils.CreatePaginationMeta(filter.Page, filter.Limit, total)
+	return utils.SuccessWithMetaResponse(c, fiber.StatusOK, "Movies retrieved successfully", data, meta)
+}
+
+// CreateGenre godoc
+// @Summary Create a genre
+// @Description Create a genre that is not linked to a TMDB genre
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param genre body GenreRequest true "Genre request object"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 201 {object} utils.StandardResponse "Genre created successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid request body"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 409 {object} utils.StandardResponse "Genre name already exists"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /genres [post]
+func (h *MovieHandler) CreateGenre(c *fiber.Ctx) error {
+	var req GenreRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	genre, err := h.service.CreateGenre(c.Context(), req.Name)
+	if err != nil {
+		return h.genreErrorResponse(c, err, "Failed to create genre")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Genre created successfully", genre)
+}
+
+// RenameGenre godoc
+// @Summary Rename a genre
+// @Description Change the name of a genre
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param id path int true "Genre ID"
+// @Param genre body GenreRequest true "Genre request object"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Genre renamed successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid request"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Genre not found"
+// @Failure 409 {object} utils.StandardResponse "Genre name already exists"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /genres/{id} [put]
+func (h *MovieHandler) RenameGenre(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid genre ID")
+	}
+
+	var req GenreRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	genre, err := h.service.RenameGenre(c.Context(), uint(id), req.Name)
+	if err != nil {
+		return h.genreErrorResponse(c, err, "Failed to rename genre")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Genre renamed successfully", genre)
+}
+
+// MergeGenres godoc
+// @Summary Merge a genre into another
+// @Description Move every movie of the source genre into this genre and delete the source. Movies already in both genres keep a single link.
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param id path int true "Target genre ID"
+// @Param merge body GenreMergeRequest true "Genre to merge into the target"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Genres merged successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid request"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Genre not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /genres/{id}/merge [post]
+func (h *MovieHandler) MergeGenres(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid genre ID")
+	}
+
+	var req GenreMergeRequest
+	if err := c.BodyParser(&req); err != nil || req.SourceID == 0 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "source_id is required")
+	}
+
+	genre, err := h.service.MergeGenres(c.Context(), req.SourceID, uint(id))
+	if err != nil {
+		return h.genreErrorResponse(c, err, "Failed to merge genres")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Genres merged successfully", genre)
+}
+
+func (h *MovieHandler) genreErrorResponse(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, services.ErrGenreNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Genre not found")
+	case errors.Is(err, services.ErrGenreNameExists):
+		return utils.ErrorResponse(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrGenreNameRequired), errors.Is(err, services.ErrGenreSelfMerge):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	h.logger.WithError(err).Error(message)
+	return utils.ErrorResponse(c, fiber.StatusInternalServerError, message)
+}
@@ -0,0 +1,9 @@
+package handlers
+
+type LanguageRequest struct {
+	Name string `json:"name" example:"English"`
+}
+
+type LanguageTranslationRequest struct {
+	Name string `json:"name" example:"Inggris"`
+}
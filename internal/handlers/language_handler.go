@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"movie-backend/internal/services"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetLanguages godoc
+// @Summary Get all languages
+// @Description Get all languages with the number of movies in each language. display_name is localized with Accept-Language and falls back to the English name.
+// @Tags languages
+// @Accept json
+// @Produce json
+// @Param Accept-Language header string false "Preferred locales for display_name (e.g. id-ID, en;q=0.8)"
+// @Success 200 {object} utils.StandardResponse "List of languages"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /languages [get]
+func (h *MovieHandler) GetLanguages(c *fiber.Ctx) error {
+	c.Vary(fiber.HeaderAcceptLanguage)
+
+	languages, err := h.service.GetLanguages(c.Context(), utils.AcceptedLocales(c))
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get languages")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve languages")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Languages retrieved successfully", languages)
+}
+
+// GetLanguage godoc
+// @Summary Get language by ID
+// @Description Get a language with its translations and movie count
+// @Tags languages
+// @Accept json
+// @Produce json
+// @Param id path int true "Language ID"
+// @Param Accept-Language header string false "Preferred locales for display_name (e.g. id-ID, en;q=0.8)"
+// @Success 200 {object} utils.StandardResponse "Language details"
+// @Failure 400 {object} utils.StandardResponse "Invalid language ID"
+// @Failure 404 {object} utils.StandardResponse "Language not found"
+// @Router /languages/{id} [get]
+func (h *MovieHandler) GetLanguage(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid language ID")
+	}
+
+	c.Vary(fiber.HeaderAcceptLanguage)
+
+	language, err := h.service.GetLanguage(c.Context(), uint(id), utils.AcceptedLocales(c))
+	if err != nil {
+		return h.languageErrorResponse(c, err, "Failed to retrieve language")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Language retrieved successfully", language)
+}
+
+// UpdateLanguage godoc
+// @Summary Update a language
+// @Description Change the English name of a language
+// @Tags languages
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param id path int true "Language ID"
+// @Param language body LanguageRequest true "Language request object"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Language updated successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid request"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Language not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /languages/{id} [put]
+func (h *MovieHandler) UpdateLanguage(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid language ID")
+	}
+
+	var req LanguageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	language, err := h.service.UpdateLanguageName(c.Context(), uint(id), req.Name)
+	if err != nil {
+		return h.languageErrorResponse(c, err, "Failed to update language")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Language updated successfully", language)
+}
+
+// SetLanguageTranslation godoc
+// @Summary Set a language translation
+// @Description Create or replace the name of a language in a UI locale
+// @Tags languages
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param id path int true "Language ID"
+// @Param locale path string true "Locale (e.g. id, pt-BR)"
+// @Param translation body LanguageTranslationRequest true "Translated name"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Translation saved successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid request"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Language not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /languages/{id}/translations/{locale} [put]
+func (h *MovieHandler) SetLanguageTranslation(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid language ID")
+	}
+
+	var req LanguageTranslationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	translation, err := h.service.SetLanguageTranslation(c.Context(), uint(id), c.Params("locale"), req.Name)
+	if err != nil {
+		return h.languageErrorResponse(c, err, "Failed to save language translation")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Translation saved successfully", translation)
+}
+
+// DeleteLanguageTranslation godoc
+// @Summary Delete a language translation
+// @Description Remove the name of a language in a UI locale
+// @Tags languages
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param id path int true "Language ID"
+// @Param locale path string true "Locale (e.g. id, pt-BR)"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Translation deleted successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid request"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Translation not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /languages/{id}/translations/{locale} [delete]
+func (h *MovieHandler) DeleteLanguageTranslation(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid language ID")
+	}
+
+	if err := h.service.DeleteLanguageTranslation(c.Context(), uint(id), c.Params("locale")); err != nil {
+		return h.languageErrorResponse(c, err, "Failed to delete language translation")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Translation deleted successfully", nil)
+}
+
+func (h *MovieHandler) languageErrorResponse(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, services.ErrLanguageNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Language not found")
+	case errors.Is(err, services.ErrLanguageTranslationNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Translation not found")
+	case errors.Is(err, services.ErrLanguageNameRequired), errors.Is(err, services.ErrInvalidLocale):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	h.logger.WithError(err).Error(message)
+	return utils.ErrorResponse(c, fiber.StatusInternalServerError, message)
+}
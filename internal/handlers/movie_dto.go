@@ -5,6 +5,7 @@ type MovieRequest struct {
 	Title            string  `json:"title"`
 	OriginalTitle    string  `json:"original_title"`
 	Overview         string  `json:"overview"`
+	Tagline          string  `json:"tagline"`
 	ReleaseDate      string  `json:"release_date"`
 	PosterPath       string  `json:"poster_path"`
 	BackdropPath     string  `json:"backdrop_path"`
@@ -14,3 +15,7 @@ type MovieRequest struct {
 	Adult            bool    `json:"adult"`
 	OriginalLanguage string  `json:"original_language"`
 }
+
+type MovieLockRequest struct {
+	Fields []string `json:"fields" example:"title,poster_path"`
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"movie-backend/internal/models"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+	exportFormatXLSX   = "xlsx"
+
+	// exportFlushEvery controls how often buffered rows are pushed to the client
+	exportFlushEvery = 200
+)
+
+var exportContentTypes = map[string]string{
+	exportFormatCSV:    "text/csv; charset=utf-8",
+	exportFormatNDJSON: "application/x-ndjson",
+	exportFormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// ExportMovies godoc
+// @Summary Export movie catalog
+// @Description Stream the movie catalog, with language and genres flattened in, as CSV, NDJSON or XLSX. Accepts the same filters as the movie list.
+// @Tags movies
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format (csv, ndjson, xlsx)" default(csv)
+// @Param columns query string false "Comma separated columns (id, tmdb_id, title, original_title, overview, release_date, poster_path, backdrop_path, vote_average, vote_count, popularity, adult, language_code, language, genres, created_at, updated_at)"
+// @Param search query string false "Search by title, translated title or overview"
+// @Param sort_by query string false "Sort by field (id, title, release_date, vote_average, user_rating, popularity, created_at, updated_at)" default(updated_at)
+// @Param order query string false "Sort order (ASC/DESC)" default(DESC)
+// @Param start_date query string false "Filter by start date (YYYY-MM-DD)"
+// @Param end_date query string false "Filter by end date (YYYY-MM-DD)"
+// @Param region query string false "ISO 3166-1 country; date filters use the release dates in this country"
+// @Param max_certification query string false "Most restrictive age certification in region (e.g. PG-13), requires region"
+// @Success 200 {file} file "Exported catalog"
+// @Failure 400 {object} utils.StandardResponse "Invalid format, columns or filters"
+// @Router /movies/export [get]
+func (h *MovieHandler) ExportMovies(c *fiber.Ctx) error {
+	format := strings.ToLower(c.Query("format", exportFormatCSV))
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid export format, use csv, ndjson or xlsx")
+	}
+
+	columns, err := parseExportColumns(c.Query("columns", ""))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	// The stream writer runs after the handler returns, so the filter must not
+	// reference the request buffer
+	filter, err := movieFilterFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	filename := fmt.Sprintf("movies-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		writer, err := newExportWriter(format, w, columns)
+		if err != nil {
+			h.logger.WithError(err).Error("Failed to start movie export")
+			return
+		}
+
+		rows := 0
+		err = h.service.ExportMovies(context.Background(), filter, func(row *models.MovieExportRow) error {
+			if err := writer.WriteRow(row); err != nil {
+				return err
+			}
+			rows++
+			if rows%exportFlushEvery == 0 {
+				return w.Flush()
+			}
+			return nil
+		})
+		if err != nil {
+			h.logger.WithError(err).WithField("rows", rows).Error("Movie export interrupted")
+		}
+
+		if err := writer.Close(); err != nil {
+			h.logger.WithError(err).Error("Failed to finish movie export")
+		}
+		_ = w.Flush()
+
+		h.logger.WithField("format", format).WithField("rows", rows).Info("Movie export completed")
+	})
+
+	return nil
+}
+
+func parseExportColumns(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return models.MovieExportColumns, nil
+	}
+
+	valid := make(map[string]bool, len(models.MovieExportColumns))
+	for _, column := range models.MovieExportColumns {
+		valid[column] = true
+	}
+
+	var columns []string
+	for _, column := range strings.Split(value, ",") {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if column == "" {
+			continue
+		}
+		if !valid[column] {
+			return nil, fmt.Errorf("unknown export column %q", column)
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("at least one export column is required")
+	}
+	return columns, nil
+}
+
+// exportWriter encodes export rows in a specific file format
+type exportWriter interface {
+	WriteRow(row *models.MovieExportRow) error
+	Close() error
+}
+
+func newExportWriter(format string, w io.Writer, columns []string) (exportWriter, error) {
+	switch format {
+	case exportFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(columns); err != nil {
+			return nil, err
+		}
+		return &csvExportWriter{writer: writer, columns: columns}, nil
+	case exportFormatNDJSON:
+		return &ndjsonExportWriter{writer: w, columns: columns}, nil
+	case exportFormatXLSX:
+		writer, err := utils.NewXLSXStreamWriter(w, "Movies")
+		if err != nil {
+			return nil, err
+		}
+		header := make([]interface{}, len(columns))
+		for i, column := range columns {
+			header[i] = column
+		}
+		if err := writer.WriteRow(header); err != nil {
+			return nil, err
+		}
+		return &xlsxExportWriter{writer: writer, columns: columns}, nil
+	}
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+type csvExportWriter struct {
+	writer  *csv.Writer
+	columns []string
+}
+
+func (w *csvExportWriter) WriteRow(row *models.MovieExportRow) error {
+	record := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		record[i] = fmt.Sprint(row.Value(column))
+	}
+	return w.writer.Write(record)
+}
+
+func (w *csvExportWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type ndjsonExportWriter struct {
+	writer  io.Writer
+	columns []string
+}
+
+// WriteRow encodes the row as a JSON object, keeping the requested column order
+func (w *ndjsonExportWriter) WriteRow(row *models.MovieExportRow) error {
+	line := []byte{'{'}
+	for i, column := range w.columns {
+		value, err := json.Marshal(row.Value(column))
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			line = append(line, ',')
+		}
+		line = append(line, '"')
+		line = append(line, column...)
+		line = append(line, '"', ':')
+		line = append(line, value...)
+	}
+	line = append(line, '}', '\n')
+
+	_, err := w.writer.Write(line)
+	return err
+}
+
+func (w *ndjsonExportWriter) Close() error {
+	return nil
+}
+
+type xlsxExportWriter struct {
+	writer  *utils.XLSXStreamWriter
+	columns []string
+}
+
+func (w *xlsxExportWriter) WriteRow(row *models.MovieExportRow) error {
+	values := make([]interface{}, len(w.columns))
+	for i, column := range w.columns {
+		values[i] = row.Value(column)
+	}
+	return w.writer.WriteRow(values)
+}
+
+func (w *xlsxExportWriter) Close() error {
+	return w.writer.Close()
+}
@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"strconv"
+	"time"
 
 	"movie-backend/internal/models"
 	"movie-backend/internal/services"
@@ -26,48 +29,80 @@ func NewMovieHandler(service services.MovieService, logger *logrus.Logger) *Movi
 
 // GetAllMovies godoc
 // @Summary Get all movies
-// @Description Get list of all movies with pagination, search, sorting, and date range filter
+// @Description Get list of all movies with pagination, search, sorting, and date range filter. For signed-in users each movie also has in_watchlist, is_favorite, is_watched and watched_at.
 // @Tags movies
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(20)
-// @Param search query string false "Search by title or overview"
-// @Param sort_by query string false "Sort by field (id, title, release_date, vote_average, popularity, created_at, updated_at)" default(updated_at)
+// @Param search query string false "Search by title, translated title or overview"
+// @Param sort_by query string false "Sort by field (id, title, release_date, vote_average, user_rating, popularity, created_at, updated_at)" default(updated_at)
 // @Param order query string false "Sort order (ASC/DESC)" default(DESC)
 // @Param start_date query string false "Filter by start date (YYYY-MM-DD)"
 // @Param end_date query string false "Filter by end date (YYYY-MM-DD)"
+// @Param region query string false "ISO 3166-1 country; date filters use the release dates in this country"
+// @Param max_certification query string false "Most restrictive age certification in region (e.g. PG-13), requires region"
+// @Param genre_id query int false "Filter by genre ID"
+// @Param tag_id query int false "Filter by editor tag ID"
+// @Param keyword_id query int false "Filter by TMDB keyword ID"
+// @Param has_trailer query bool false "Only movies with (true) or without (false) a trailer"
+// @Param provider query int false "Only movies available with this watch provider ID, in region when given"
+// @Param fields query string false "Comma separated fields to return (e.g. id,title,poster_path)"
+// @Param expand query string false "Comma separated relations to load (genres, language, collection, keywords, tags). Keywords and tags are only loaded when listed; the others are loaded when omitted"
+// @Param lang query string false "Locale for title, overview and tagline (e.g. id). Overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred locales for title, overview and tagline; English is the fallback"
 // @Success 200 {object} utils.StandardResponse "List of movies"
+// @Failure 400 {object} utils.StandardResponse "Invalid filters, fields or expand"
 // @Failure 500 {object} utils.StandardResponse "Internal server error"
 // @Router /movies [get]
 func (h *MovieHandler) GetAllMovies(c *fiber.Ctx) error {
 	ctx := c.Context()
 
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "20"))
-	search := c.Query("search", "")
-	sortBy := c.Query("sort_by", "updated_at")
-	order := c.Query("order", "DESC")
-	startDate := c.Query("start_date", "")
-	endDate := c.Query("end_date", "")
+	filter, err := movieFilterFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+	projection, err := movieProjectionFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if h.catalogNotModified(c) {
+		return utils.NotModifiedResponse(c)
+	}
 
-	movies, total, err := h.service.GetAllMovies(ctx, page, limit, search, sortBy, order, startDate, endDate)
+	movies, total, err := h.service.GetAllMovies(ctx, filter, projection)
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to get movies")
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve movies")
 	}
 
-	meta := utils.CreatePaginationMeta(page, limit, total)
-	return utils.SuccessWithMetaResponse(c, fiber.StatusOK, "Movies retrieved successfully", movies, meta)
+	if err := h.annotateUserMovies(c, movies); err != nil {
+		h.logger.WithError(err).Error("Failed to load personal movie lists")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve movies")
+	}
+
+	data, err := projectMovies(movies, projection)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to project movies")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve movies")
+	}
+
+	meta := utils.CreatePaginationMeta(filter.Page, filter.Limit, total)
+	return utils.SuccessWithMetaResponse(c, fiber.StatusOK, "Movies retrieved successfully", data, meta)
 }
 
 // GetMovieByID godoc
 // @Summary Get movie by ID
-// @Description Get a single movie by its ID
+// @Description Get a single movie by its ID. For signed-in users it also has in_watchlist, is_favorite, is_watched and watched_at.
 // @Tags movies
 // @Accept json
 // @Produce json
 // @Param id path int true "Movie ID"
+// @Param fields query string false "Comma separated fields to return (e.g. id,title,poster_path)"
+// @Param expand query string false "Comma separated relations to load (genres, language, collection, keywords, tags). Keywords and tags are only loaded when listed; the others are loaded when omitted"
+// @Param lang query string false "Locale for title, overview and tagline (e.g. id). Overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred locales for title, overview and tagline; English is the fallback"
 // @Success 200 {object} utils.StandardResponse "Movie details"
 // @Failure 400 {object} utils.StandardResponse "Invalid movie ID"
 // @Failure 404 {object} utils.StandardResponse "Movie not found"
@@ -80,13 +115,45 @@ func (h *MovieHandler) GetMovieByID(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
 	}
 
-	movie, err := h.service.GetMovieByID(ctx, uint(id))
+	projection, err := movieProjectionFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	movie, err := h.service.GetMovieByID(ctx, uint(id), projection)
 	if err != nil {
 		h.logger.WithError(err).WithField("id", id).Error("Failed to get movie")
 		return utils.ErrorResponse(c, fiber.StatusNotFound, "Movie not found")
 	}
 
-	return utils.SuccessResponse(c, fiber.StatusOK, "Movie retrieved successfully", movie)
+	personal := personalUserID(c) != 0
+	if !personal && utils.NotModified(c, movieETag(movie), movieLastModified(movie)) {
+		return utils.NotModifiedResponse(c)
+	}
+	movies := []models.Movie{*movie}
+	if err := h.annotateUserMovies(c, movies); err != nil {
+		h.logger.WithError(err).WithField("id", id).Error("Failed to load personal movie lists")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve movie")
+	}
+	movie = &movies[0]
+
+	// The lists don't change the movie version or its modification time, so
+	// personal responses are tagged with them and revalidated by ETag only
+	if personal {
+		notModified := utils.NotModified(c, personalMovieETag(movie), time.Time{})
+		c.Set(fiber.HeaderCacheControl, "private, no-cache")
+		if notModified {
+			return utils.NotModifiedResponse(c)
+		}
+	}
+
+	data, err := projectMovie(movie, projection)
+	if err != nil {
+		h.logger.WithError(err).WithField("id", id).Error("Failed to project movie")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve movie")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Movie retrieved successfully", data)
 }
 
 // CreateMovie godoc
@@ -95,13 +162,18 @@ func (h *MovieHandler) GetMovieByID(c *fiber.Ctx) error {
 // @Tags movies
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Param movie body MovieRequest true "Movie request object"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
 // @Success 201 {object} utils.StandardResponse "Movie created successfully"
 // @Failure 400 {object} utils.StandardResponse "Invalid request body"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
 // @Failure 500 {object} utils.StandardResponse "Internal server error"
 // @Router /movies [post]
 func (h *MovieHandler) CreateMovie(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := auditContext(c)
 
 	var req MovieRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -129,14 +201,22 @@ func (h *MovieHandler) CreateMovie(c *fiber.Ctx) error {
 // @Tags movies
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Param id path int true "Movie ID"
 // @Param movie body MovieRequest true "Movie request object"
+// @Param If-Match header string false "ETag of the movie the edit is based on; the update fails with 412 if it changed"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
 // @Success 200 {object} utils.StandardResponse "Movie updated successfully"
 // @Failure 400 {object} utils.StandardResponse "Invalid request"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Movie not found"
+// @Failure 412 {object} utils.StandardResponse "Movie was modified, data holds the current movie"
 // @Failure 500 {object} utils.StandardResponse "Internal server error"
 // @Router /movies/{id} [put]
 func (h *MovieHandler) UpdateMovie(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := auditContext(c)
 
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
@@ -154,36 +234,102 @@ func (h *MovieHandler) UpdateMovie(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
 	}
 
+	movie.Version = ifMatchVersion(c, uint(id))
 	if err := h.service.UpdateMovie(ctx, uint(id), movie); err != nil {
-		h.logger.WithError(err).WithField("id", id).Error("Failed to update movie")
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, err.Error())
+		return h.movieWriteErrorResponse(c, err, uint(id), "Failed to update movie")
+	}
+
+	c.Set(fiber.HeaderETag, movieETag(movie))
+	return utils.SuccessResponse(c, fiber.StatusOK, "Movie updated successfully", movie)
+}
+
+// PatchMovie godoc
+// @Summary Partially update a movie
+// @Description Update only the fields present in the body; other fields keep their current value
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param id path int true "Movie ID"
+// @Param movie body MovieRequest true "Fields to change"
+// @Param If-Match header string false "ETag of the movie the edit is based on; the update fails with 412 if it changed"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Movie updated successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid request"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Movie not found"
+// @Failure 412 {object} utils.StandardResponse "Movie was modified, data holds the current movie"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/{id} [patch]
+func (h *MovieHandler) PatchMovie(c *fiber.Ctx) error {
+	ctx := auditContext(c)
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+
+	current, err := h.service.GetMovieByID(ctx, uint(id), models.MovieProjection{Expand: []string{"language"}})
+	if err != nil {
+		return h.movieWriteErrorResponse(c, err, uint(id), "Failed to update movie")
 	}
 
+	// Fields missing from the body keep the values copied from the current movie
+	req := movieRequestFromMovie(current)
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	movie, err := h.convertRequestToMovie(ctx, &req)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to convert request to movie")
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	// Without If-Match the patch is still based on the version read above
+	movie.Version = current.Version
+	if version := ifMatchVersion(c, uint(id)); version != 0 {
+		movie.Version = version
+	}
+	if err := h.service.UpdateMovie(ctx, uint(id), movie); err != nil {
+		return h.movieWriteErrorResponse(c, err, uint(id), "Failed to update movie")
+	}
+
+	c.Set(fiber.HeaderETag, movieETag(movie))
 	return utils.SuccessResponse(c, fiber.StatusOK, "Movie updated successfully", movie)
 }
 
 // DeleteMovie godoc
 // @Summary Delete a movie
-// @Description Delete a movie by ID
+// @Description Move a movie to the trash. It can be restored until it is purged after the retention window.
 // @Tags movies
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Param id path int true "Movie ID"
+// @Param If-Match header string false "ETag of the movie; the delete fails with 412 if it changed"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
 // @Success 200 {object} utils.StandardResponse "Movie deleted successfully"
 // @Failure 400 {object} utils.StandardResponse "Invalid movie ID"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Movie not found"
+// @Failure 412 {object} utils.StandardResponse "Movie was modified, data holds the current movie"
 // @Failure 500 {object} utils.StandardResponse "Internal server error"
 // @Router /movies/{id} [delete]
 func (h *MovieHandler) DeleteMovie(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := auditContext(c)
 
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
 	}
 
-	if err := h.service.DeleteMovie(ctx, uint(id)); err != nil {
-		h.logger.WithError(err).WithField("id", id).Error("Failed to delete movie")
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, err.Error())
+	if err := h.service.DeleteMovie(ctx, uint(id), ifMatchVersion(c, uint(id))); err != nil {
+		return h.movieWriteErrorResponse(c, err, uint(id), "Failed to delete movie")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Movie deleted successfully", nil)
@@ -195,12 +341,17 @@ func (h *MovieHandler) DeleteMovie(c *fiber.Ctx) error {
 // @Tags sync
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Param pages query int false "Number of pages to sync (1-10)" default(1)
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
 // @Success 200 {object} utils.StandardResponse "Sync completed successfully"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the admin role"
 // @Failure 500 {object} utils.StandardResponse "Sync failed"
 // @Router /sync/movies [post]
 func (h *MovieHandler) SyncMoviesFromTMDB(c *fiber.Ctx) error {
-	ctx := c.Context()
+	ctx := auditContext(c)
 
 	pages, _ := strconv.Atoi(c.Query("pages", "1"))
 
@@ -217,17 +368,28 @@ func (h *MovieHandler) SyncMoviesFromTMDB(c *fiber.Ctx) error {
 
 // GetDashboardStats godoc
 // @Summary Get dashboard statistics
-// @Description Get comprehensive dashboard analytics
+// @Description Get comprehensive dashboard analytics, including the catalog coverage of the top watch providers
 // @Tags dashboard
 // @Accept json
 // @Produce json
+// @Param region query string false "ISO 3166-1 country for the provider coverage breakdown; all regions when omitted"
 // @Success 200 {object} utils.StandardResponse "Dashboard statistics"
+// @Failure 400 {object} utils.StandardResponse "Invalid region"
 // @Failure 500 {object} utils.StandardResponse "Failed to retrieve statistics"
 // @Router /dashboard/stats [get]
 func (h *MovieHandler) GetDashboardStats(c *fiber.Ctx) error {
 	ctx := c.Context()
 
-	stats, err := h.service.GetDashboardStats(ctx)
+	region, err := regionFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if h.catalogNotModified(c) {
+		return utils.NotModifiedResponse(c)
+	}
+
+	stats, err := h.service.GetDashboardStats(ctx, region)
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to get dashboard stats")
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve dashboard statistics")
@@ -242,7 +404,11 @@ func (h *MovieHandler) GetDashboardStats(c *fiber.Ctx) error {
 // @Tags sync
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Success 200 {object} utils.StandardResponse "Last sync log"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
 // @Failure 500 {object} utils.StandardResponse "Failed to retrieve sync log"
 // @Router /sync/last-log [get]
 func (h *MovieHandler) GetLastSyncLog(c *fiber.Ctx) error {
@@ -269,7 +435,9 @@ func (h *MovieHandler) GetLastSyncLog(c *fiber.Ctx) error {
 // @Produce json
 // @Param start_date query string false "Filter by start date (YYYY-MM-DD)"
 // @Param end_date query string false "Filter by end date (YYYY-MM-DD)"
+// @Param region query string false "ISO 3166-1 country; years come from the release dates in this country"
 // @Success 200 {object} utils.StandardResponse "Chart data"
+// @Failure 400 {object} utils.StandardResponse "Invalid dates or region"
 // @Failure 500 {object} utils.StandardResponse "Failed to retrieve chart data"
 // @Router /charts [get]
 func (h *MovieHandler) GetChartData(c *fiber.Ctx) error {
@@ -277,8 +445,19 @@ func (h *MovieHandler) GetChartData(c *fiber.Ctx) error {
 
 	startDate := c.Query("start_date", "")
 	endDate := c.Query("end_date", "")
+	region, err := regionFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
 
-	chartData, err := h.service.GetChartData(ctx, startDate, endDate)
+	if h.catalogNotModified(c) {
+		return utils.NotModifiedResponse(c)
+	}
+
+	chartData, err := h.service.GetChartData(ctx, startDate, endDate, region)
+	if errors.Is(err, services.ErrInvalidChartDate) {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to get chart data")
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve chart data")
@@ -299,6 +478,10 @@ func (h *MovieHandler) GetChartData(c *fiber.Ctx) error {
 func (h *MovieHandler) GetPieChartData(c *fiber.Ctx) error {
 	ctx := c.Context()
 
+	if h.catalogNotModified(c) {
+		return utils.NotModifiedResponse(c)
+	}
+
 	data, err := h.service.GetMoviesByLanguage(ctx)
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to get pie chart data")
@@ -316,7 +499,9 @@ func (h *MovieHandler) GetPieChartData(c *fiber.Ctx) error {
 // @Produce json
 // @Param start_date query string false "Filter by start date (YYYY-MM-DD)"
 // @Param end_date query string false "Filter by end date (YYYY-MM-DD)"
+// @Param region query string false "ISO 3166-1 country; years come from the release dates in this country"
 // @Success 200 {object} utils.StandardResponse "Column chart data"
+// @Failure 400 {object} utils.StandardResponse "Invalid dates or region"
 // @Failure 500 {object} utils.StandardResponse "Failed to retrieve column chart data"
 // @Router /charts/column [get]
 func (h *MovieHandler) GetColumnChartData(c *fiber.Ctx) error {
@@ -324,8 +509,19 @@ func (h *MovieHandler) GetColumnChartData(c *fiber.Ctx) error {
 
 	startDate := c.Query("start_date", "")
 	endDate := c.Query("end_date", "")
+	region, err := regionFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if h.catalogNotModified(c) {
+		return utils.NotModifiedResponse(c)
+	}
 
-	data, err := h.service.GetMoviesByYear(ctx, startDate, endDate)
+	data, err := h.service.GetMoviesByYear(ctx, startDate, endDate, region)
+	if errors.Is(err, services.ErrInvalidChartDate) {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to get column chart data")
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve column chart data")
@@ -341,8 +537,9 @@ func (h *MovieHandler) GetColumnChartData(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param year path int true "Year (e.g., 2024)"
+// @Param region query string false "ISO 3166-1 country; months come from the release dates in this country"
 // @Success 200 {object} utils.StandardResponse "Monthly chart data"
-// @Failure 400 {object} utils.StandardResponse "Invalid year"
+// @Failure 400 {object} utils.StandardResponse "Invalid year or region"
 // @Failure 500 {object} utils.StandardResponse "Failed to retrieve monthly chart data"
 // @Router /charts/monthly/{year} [get]
 func (h *MovieHandler) GetMonthlyChartData(c *fiber.Ctx) error {
@@ -352,8 +549,16 @@ func (h *MovieHandler) GetMonthlyChartData(c *fiber.Ctx) error {
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid year format")
 	}
+	region, err := regionFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if h.catalogNotModified(c) {
+		return utils.NotModifiedResponse(c)
+	}
 
-	data, err := h.service.GetMoviesByMonth(ctx, year)
+	data, err := h.service.GetMoviesByMonth(ctx, year, region)
 	if err != nil {
 		h.logger.WithError(err).WithField("year", year).Error("Failed to get monthly chart data")
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve monthly chart data")
@@ -362,6 +567,21 @@ func (h *MovieHandler) GetMonthlyChartData(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, fiber.StatusOK, "Monthly chart data retrieved successfully", data)
 }
 
+// catalogNotModified sets a weak ETag and Last-Modified derived from the
+// catalog version and reports whether the client's copy is still current.
+// Lists personal to a signed-in user are always sent in full.
+func (h *MovieHandler) catalogNotModified(c *fiber.Ctx) bool {
+	if personalUserID(c) != 0 {
+		return false
+	}
+	state, err := h.service.GetCatalogState(c.Context())
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to read catalog version")
+		return false
+	}
+	return utils.NotModified(c, utils.WeakETag(c, state.Version), state.UpdatedAt)
+}
+
 func (h *MovieHandler) convertRequestToMovie(ctx context.Context, req *MovieRequest) (*models.Movie, error) {
 	langSvc, ok := h.service.(interface {
 		GetLanguageByCode(context.Context, string) (*models.Language, error)
@@ -387,6 +607,7 @@ func (h *MovieHandler) convertRequestToMovie(ctx context.Context, req *MovieRequ
 		Title:         req.Title,
 		OriginalTitle: req.OriginalTitle,
 		Overview:      req.Overview,
+		Tagline:       req.Tagline,
 		ReleaseDate:   req.ReleaseDate,
 		PosterPath:    req.PosterPath,
 		BackdropPath:  req.BackdropPath,
@@ -400,6 +621,29 @@ func (h *MovieHandler) convertRequestToMovie(ctx context.Context, req *MovieRequ
 	return movie, nil
 }
 
+// movieRequestFromMovie is the inverse of convertRequestToMovie, used as the
+// base a PATCH body is applied to
+func movieRequestFromMovie(movie *models.Movie) MovieRequest {
+	req := MovieRequest{
+		TMDBID:        movie.TMDBID,
+		Title:         movie.Title,
+		OriginalTitle: movie.OriginalTitle,
+		Overview:      movie.Overview,
+		Tagline:       movie.Tagline,
+		ReleaseDate:   movie.ReleaseDate,
+		PosterPath:    movie.PosterPath,
+		BackdropPath:  movie.BackdropPath,
+		VoteAverage:   movie.VoteAverage,
+		VoteCount:     movie.VoteCount,
+		Popularity:    movie.Popularity,
+		Adult:         movie.Adult,
+	}
+	if movie.Language != nil {
+		req.OriginalLanguage = movie.Language.Code
+	}
+	return req
+}
+
 func getLanguageName(langCode string) string {
 	langMap := map[string]string{
 		"en": "English", "ja": "Japanese", "ko": "Korean", "zh": "Chinese",
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"movie-backend/internal/models"
+	"movie-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// projectingMovieService returns its movie with only the columns a
+// ?fields= projection selects, like the repository does
+type projectingMovieService struct {
+	services.MovieService
+	movie models.Movie
+}
+
+func (s *projectingMovieService) GetMovieByID(_ context.Context, _ uint, projection models.MovieProjection) (*models.Movie, error) {
+	movie := s.movie
+	if len(projection.Fields) == 0 {
+		return &movie, nil
+	}
+
+	data, err := json.Marshal(movie)
+	if err != nil {
+		return nil, err
+	}
+	var columns map[string]json.RawMessage
+	if err := json.Unmarshal(data, &columns); err != nil {
+		return nil, err
+	}
+	selected := map[string]json.RawMessage{}
+	for _, column := range append(projection.Fields, models.MovieETagColumns...) {
+		if value, ok := columns[column]; ok {
+			selected[column] = value
+		}
+	}
+	if data, err = json.Marshal(selected); err != nil {
+		return nil, err
+	}
+	var projected models.Movie
+	return &projected, json.Unmarshal(data, &projected)
+}
+
+func TestGetMovieByIDProjectionKeepsTheETag(t *testing.T) {
+	updatedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	service := &projectingMovieService{movie: models.Movie{ID: 42, Title: "The Matrix", Version: 7, UpdatedAt: updatedAt}}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	app := fiber.New()
+	app.Get("/movies/:id", NewMovieHandler(service, logger).GetMovieByID)
+
+	get := func(target, ifNoneMatch string) (int, string) {
+		t.Helper()
+		req := httptest.NewRequest(fiber.MethodGet, target, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set(fiber.HeaderIfNoneMatch, ifNoneMatch)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", target, err)
+		}
+		return resp.StatusCode, resp.Header.Get(fiber.HeaderETag)
+	}
+
+	_, full := get("/movies/42", "")
+	if full != `"42-v7"` {
+		t.Fatalf("got ETag %s for the full movie, want %s", full, `"42-v7"`)
+	}
+	for _, target := range []string{"/movies/42?fields=title", "/movies/42?fields=id,title&expand="} {
+		if _, projected := get(target, ""); projected != full {
+			t.Errorf("GET %s: got ETag %s, want the full movie's %s", target, projected, full)
+		}
+		// A client holding an older version must get the movie, not a 304
+		if status, _ := get(target, `"42-v6"`); status != fiber.StatusOK {
+			t.Errorf("GET %s with a stale If-None-Match: got status %d, want %d", target, status, fiber.StatusOK)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"movie-backend/internal/audit"
+	"movie-backend/internal/repository"
+	"movie-backend/internal/services"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// auditContext returns the request context carrying the actor that movie
+// revisions are attributed to
+func auditContext(c *fiber.Ctx) context.Context {
+	actor := audit.Actor{
+		Source:    audit.SourceAPI,
+		RequestID: utils.RequestID(c),
+	}
+	if name, ok := c.Locals(audit.LocalsActorKey).(string); ok {
+		actor.Name = name
+	}
+	if role, ok := c.Locals(audit.LocalsActorRoleKey).(string); ok {
+		actor.Role = role
+	}
+	return audit.WithActor(c.Context(), actor)
+}
+
+// GetMovieHistory godoc
+// @Summary Get movie revision history
+// @Description Get the revisions of a movie, newest first. Each revision has the actor, the source (api, sync or import) and the changed fields with their old and new values.
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Movie ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} utils.StandardResponse "List of revisions"
+// @Failure 400 {object} utils.StandardResponse "Invalid movie ID"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/{id}/history [get]
+func (h *MovieHandler) GetMovieHistory(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	revisions, total, err := h.service.GetMovieHistory(c.Context(), uint(id), page, limit)
+	if err != nil {
+		h.logger.WithError(err).WithField("id", id).Error("Failed to get movie history")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve movie history")
+	}
+
+	meta := utils.CreatePaginationMeta(page, limit, total)
+	return utils.SuccessWithMetaResponse(c, fiber.StatusOK, "Movie history retrieved successfully", revisions, meta)
+}
+
+// RevertMovie godoc
+// @Summary Revert a movie to a revision
+// @Description Restore the fields and genres of a movie to their state after the given revision. The revert is recorded as a new revision.
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param id path int true "Movie ID"
+// @Param revision path int true "Revision number"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Movie reverted successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid movie ID or revision"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Movie or revision not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/{id}/revert/{revision} [post]
+func (h *MovieHandler) RevertMovie(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+	revision, err := strconv.Atoi(c.Params("revision"))
+	if err != nil || revision < 1 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid revision")
+	}
+
+	movie, err := h.service.RevertMovie(auditContext(c), uint(id), revision)
+	if err != nil {
+		if errors.Is(err, services.ErrRevisionNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Revision not found")
+		}
+		if errors.Is(err, repository.ErrMovieNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Movie not found")
+		}
+		h.logger.WithError(err).WithField("id", id).Error("Failed to revert movie")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to revert movie")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Movie reverted successfully", movie)
+}
@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"movie-backend/internal/constants"
+	"movie-backend/internal/models"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	importFormatCSV    = "csv"
+	importFormatJSON   = "json"
+	importFormatNDJSON = "ndjson"
+)
+
+// ImportMovies godoc
+// @Summary Bulk import movies
+// @Description Import movies from a CSV, JSON array or NDJSON file. Columns map to the movie request fields; "original_language" accepts a code or name and "genres" accepts names separated by "|". Send the file as multipart field "file" or as the raw request body.
+// @Tags movies
+// @Accept mpfd
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param file formData file false "Import file"
+// @Param format query string false "File format (csv, json, ndjson). Detected from file name or Content-Type when omitted"
+// @Param upsert query bool false "Update existing movies matched by tmdb_id instead of failing" default(false)
+// @Param atomic query bool false "Roll back the whole import if any row fails" default(false)
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Import report"
+// @Failure 400 {object} utils.StandardResponse "Invalid import file"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 422 {object} utils.StandardResponse "Import rolled back"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/import [post]
+func (h *MovieHandler) ImportMovies(c *fiber.Ctx) error {
+	ctx := auditContext(c)
+
+	reader, filename, err := importSource(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+	if closer, ok := reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	format := detectImportFormat(c.Query("format"), filename, c.Get(fiber.HeaderContentType))
+	if format == "" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Unable to detect import format, use format=csv|json|ndjson")
+	}
+
+	records, err := parseImportRecords(format, reader)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+	if len(records) == 0 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Import file contains no rows")
+	}
+
+	opts := models.MovieImportOptions{
+		Upsert: c.QueryBool("upsert", false),
+		Atomic: c.QueryBool("atomic", false),
+	}
+
+	report, err := h.service.ImportMovies(ctx, records, opts)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to import movies")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if report.RolledBack {
+		return utils.ErrorWithDataResponse(c, fiber.StatusUnprocessableEntity, "Import rolled back because some rows failed", report)
+	}
+
+	message := "Movies imported successfully"
+	if report.Failed > 0 {
+		message = fmt.Sprintf("Movies imported with %d failed rows", report.Failed)
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, message, report)
+}
+
+// importSource returns the uploaded file when the request is multipart,
+// otherwise the raw request body
+func importSource(c *fiber.Ctx) (io.Reader, string, error) {
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return nil, "", errors.New("multipart field \"file\" is required")
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to open uploaded file: %v", err)
+		}
+		return file, fileHeader.Filename, nil
+	}
+
+	body := c.Body()
+	if len(body) == 0 {
+		return nil, "", errors.New("request body is empty")
+	}
+	return bytes.NewReader(body), "", nil
+}
+
+func detectImportFormat(format, filename, contentType string) string {
+	switch strings.ToLower(format) {
+	case importFormatCSV:
+		return importFormatCSV
+	case importFormatJSON:
+		return importFormatJSON
+	case importFormatNDJSON, "jsonl":
+		return importFormatNDJSON
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return importFormatCSV
+	case ".json":
+		return importFormatJSON
+	case ".ndjson", ".jsonl":
+		return importFormatNDJSON
+	}
+
+	contentType = strings.ToLower(contentType)
+	switch {
+	case strings.Contains(contentType, "csv"):
+		return importFormatCSV
+	case strings.Contains(contentType, "ndjson"), strings.Contains(contentType, "jsonl"):
+		return importFormatNDJSON
+	case strings.Contains(contentType, "json"):
+		return importFormatJSON
+	}
+	return ""
+}
+
+func parseImportRecords(format string, r io.Reader) ([]models.MovieImportRecord, error) {
+	switch format {
+	case importFormatCSV:
+		return parseImportCSV(r)
+	case importFormatJSON:
+		return parseImportJSON(r)
+	case importFormatNDJSON:
+		return parseImportNDJSON(r)
+	}
+	return nil, fmt.Errorf("unsupported import format %q", format)
+}
+
+// parseImportCSV reads a CSV file whose first row holds the column names.
+// Row numbers in the report match the line in the file.
+func parseImportCSV(r io.Reader) ([]models.MovieImportRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %v", err)
+	}
+
+	columns := make([]string, len(header))
+	known := 0
+	for i, name := range header {
+		columns[i] = normalizeImportColumn(name)
+		if columns[i] != "" {
+			known++
+		}
+	}
+	if known == 0 {
+		return nil, errors.New("CSV header contains no known movie columns")
+	}
+
+	var records []models.MovieImportRecord
+	for row := 2; ; row++ {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV at row %d: %v", row, err)
+		}
+		if len(records) >= constants.MaxImportRows {
+			return nil, fmt.Errorf("import is limited to %d rows", constants.MaxImportRows)
+		}
+
+		fields := make(map[string]string)
+		for i, value := range values {
+			if i < len(columns) && columns[i] != "" {
+				fields[columns[i]] = value
+			}
+		}
+		records = append(records, models.MovieImportRecord{Row: row, Fields: fields})
+	}
+
+	return records, nil
+}
+
+// parseImportJSON streams a JSON array of movie objects
+func parseImportJSON(r io.Reader) ([]models.MovieImportRecord, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, errors.New("JSON import must be an array of movie objects")
+	}
+
+	var records []models.MovieImportRecord
+	for row := 1; decoder.More(); row++ {
+		if len(records) >= constants.MaxImportRows {
+			return nil, fmt.Errorf("import is limited to %d rows", constants.MaxImportRows)
+		}
+
+		var object map[string]interface{}
+		if err := decoder.Decode(&object); err != nil {
+			return nil, fmt.Errorf("invalid JSON object at row %d: %v", row, err)
+		}
+		records = append(records, models.MovieImportRecord{Row: row, Fields: importFieldsFromObject(object)})
+	}
+
+	return records, nil
+}
+
+// parseImportNDJSON reads one movie object per line, skipping blank lines.
+// Row numbers in the report match the line in the file.
+func parseImportNDJSON(r io.Reader) ([]models.MovieImportRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var records []models.MovieImportRecord
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(records) >= constants.MaxImportRows {
+			return nil, fmt.Errorf("import is limited to %d rows", constants.MaxImportRows)
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.UseNumber()
+
+		var object map[string]interface{}
+		if err := decoder.Decode(&object); err != nil {
+			return nil, fmt.Errorf("invalid JSON object at line %d: %v", line, err)
+		}
+		records = append(records, models.MovieImportRecord{Row: line, Fields: importFieldsFromObject(object)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read NDJSON: %v", err)
+	}
+
+	return records, nil
+}
+
+func importFieldsFromObject(object map[string]interface{}) map[string]string {
+	fields := make(map[string]string)
+	for key, value := range object {
+		if column := normalizeImportColumn(key); column != "" {
+			fields[column] = importValueString(value)
+		}
+	}
+	return fields
+}
+
+// importValueString flattens a decoded JSON value into the raw cell format
+// used by CSV imports. Arrays become "|" separated lists and objects such as
+// {"name": "Action"} contribute their name.
+func importValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if s := importValueString(item); s != "" {
+				items = append(items, s)
+			}
+		}
+		return strings.Join(items, "|")
+	case map[string]interface{}:
+		if name, ok := v["name"]; ok {
+			return importValueString(name)
+		}
+		if code, ok := v["code"]; ok {
+			return importValueString(code)
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+// normalizeImportColumn maps a header name to an import column, or returns
+// an empty string for unknown columns
+func normalizeImportColumn(name string) string {
+	name = strings.TrimPrefix(name, "\ufeff")
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+
+	if alias, ok := models.MovieImportColumnAliases[name]; ok {
+		name = alias
+	}
+	for _, column := range models.MovieImportColumns {
+		if column == name {
+			return name
+		}
+	}
+	return ""
+}
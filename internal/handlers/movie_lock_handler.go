@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"movie-backend/internal/repository"
+	"movie-backend/internal/services"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetMovieLocks godoc
+// @Summary Get movie field locks
+// @Description Get the fields of a movie that TMDB sync leaves unchanged
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Success 200 {object} utils.StandardResponse "List of field locks"
+// @Failure 400 {object} utils.StandardResponse "Invalid movie ID"
+// @Failure 404 {object} utils.StandardResponse "Movie not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/{id}/locks [get]
+func (h *MovieHandler) GetMovieLocks(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+
+	locks, err := h.service.GetMovieLocks(c.Context(), uint(id))
+	if err != nil {
+		return h.lockErrorResponse(c, err, "Failed to retrieve field locks")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Field locks retrieved successfully", locks)
+}
+
+// LockMovieFields godoc
+// @Summary Lock movie fields
+// @Description Lock fields so TMDB sync leaves them unchanged. Fields edited through the API are locked automatically.
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param id path int true "Movie ID"
+// @Param locks body MovieLockRequest true "Fields to lock (title, original_title, overview, tagline, release_date, poster_path, backdrop_path, vote_average, vote_count, popularity, adult, language_id, genre_ids)"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Fields locked successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid request"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Movie not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/{id}/locks [post]
+func (h *MovieHandler) LockMovieFields(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+
+	var req MovieLockRequest
+	if err := c.BodyParser(&req); err != nil || len(req.Fields) == 0 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "fields is required")
+	}
+
+	locks, err := h.service.LockMovieFields(auditContext(c), uint(id), req.Fields)
+	if err != nil {
+		return h.lockErrorResponse(c, err, "Failed to lock fields")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Fields locked successfully", locks)
+}
+
+// UnlockMovieField godoc
+// @Summary Unlock a movie field
+// @Description Let TMDB sync update the field again
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param id path int true "Movie ID"
+// @Param field path string true "Field name"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Field unlocked successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid movie ID"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Field is not locked"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/{id}/locks/{field} [delete]
+func (h *MovieHandler) UnlockMovieField(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+
+	if err := h.service.UnlockMovieField(c.Context(), uint(id), c.Params("field")); err != nil {
+		return h.lockErrorResponse(c, err, "Failed to unlock field")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Field unlocked successfully", nil)
+}
+
+func (h *MovieHandler) lockErrorResponse(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, repository.ErrMovieNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Movie not found")
+	case errors.Is(err, services.ErrFieldLockNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidLockField):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	h.logger.WithError(err).Error(message)
+	return utils.ErrorResponse(c, fiber.StatusInternalServerError, message)
+}
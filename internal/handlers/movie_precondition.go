@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// movieETag builds the strong ETag of a movie from its version, e.g.
+// "42-v7", with the locale appended for translated representations and the
+// time of the last user rating change, which doesn't bump the version
+func movieETag(movie *models.Movie) string {
+	tag := fmt.Sprintf("%d-v%d", movie.ID, movie.Version)
+	if movie.Locale != "" {
+		tag += "-" + movie.Locale
+	}
+	if movie.UserRatingUpdatedAt != nil {
+		tag += fmt.Sprintf("-r%d", movie.UserRatingUpdatedAt.UnixMilli())
+	}
+	return utils.StrongETag(tag)
+}
+
+// personalMovieETag extends the movie's ETag with the caller's list state,
+// which doesn't change the movie version, e.g. "42-v7-lwf-s1700000000000".
+// If-Match still finds the version in it.
+func personalMovieETag(movie *models.Movie) string {
+	tag := strings.Trim(movieETag(movie), `"`) + "-l"
+	if movie.InWatchlist != nil && *movie.InWatchlist {
+		tag += "w"
+	}
+	if movie.IsFavorite != nil && *movie.IsFavorite {
+		tag += "f"
+	}
+	if movie.IsWatched != nil && *movie.IsWatched {
+		tag += "-s"
+		if movie.WatchedAt != nil {
+			tag += strconv.FormatInt(movie.WatchedAt.UnixMilli(), 10)
+		}
+	}
+	return utils.StrongETag(tag)
+}
+
+// movieLastModified is when the movie or its user rating last changed
+func movieLastModified(movie *models.Movie) time.Time {
+	if movie.UserRatingUpdatedAt != nil && movie.UserRatingUpdatedAt.After(movie.UpdatedAt) {
+		return *movie.UserRatingUpdatedAt
+	}
+	return movie.UpdatedAt
+}
+
+// ifMatchVersion returns the movie version named by the If-Match header. It
+// returns 0 when the header is absent or "*", so any version is accepted, and
+// -1 when no tag belongs to this movie, which never matches. Weak tags are
+// ignored because If-Match uses strong comparison.
+func ifMatchVersion(c *fiber.Ctx, id uint) int64 {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" || header == "*" {
+		return 0
+	}
+
+	prefix := fmt.Sprintf("%d-v", id)
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if strings.HasPrefix(candidate, "W/") {
+			continue
+		}
+		tag := strings.Trim(candidate, `"`)
+		if !strings.HasPrefix(tag, prefix) {
+			continue
+		}
+		value := strings.TrimPrefix(tag, prefix)
+		if i := strings.IndexByte(value, '-'); i != -1 {
+			value = value[:i] // locale and rating suffixes
+		}
+		if version, err := strconv.ParseInt(value, 10, 64); err == nil && version > 0 {
+			return version
+		}
+	}
+	return -1
+}
+
+// versionConflictResponse answers a failed If-Match (or a write that lost a
+// race) with 412 and the movie as it is now, so the client can merge and retry
+func (h *MovieHandler) versionConflictResponse(c *fiber.Ctx, id uint) error {
+	locales, _ := movieLocalesFromQuery(c)
+	current, err := h.service.GetMovieByID(c.Context(), id, models.MovieProjection{Locales: locales})
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusPreconditionFailed, "Movie was modified by another request")
+	}
+
+	c.Set(fiber.HeaderETag, movieETag(current))
+	return utils.ErrorWithDataResponse(c, fiber.StatusPreconditionFailed, "Movie was modified by another request", current)
+}
+
+// movieWriteErrorResponse maps errors of movie update and delete requests
+func (h *MovieHandler) movieWriteErrorResponse(c *fiber.Ctx, err error, id uint, message string) error {
+	switch {
+	case errors.Is(err, repository.ErrVersionConflict):
+		return h.versionConflictResponse(c, id)
+	case errors.Is(err, repository.ErrMovieNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Movie not found")
+	}
+
+	h.logger.WithError(err).WithField("id", id).Error(message)
+	return utils.ErrorResponse(c, fiber.StatusInternalServerError, err.Error())
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"movie-backend/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestPersonalMovieETag(t *testing.T) {
+	yes, no := true, false
+	watchedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	movie := models.Movie{ID: 42, Version: 7}
+
+	tests := []struct {
+		name  string
+		state func(m *models.Movie)
+		want  string
+	}{
+		{"on no list", func(m *models.Movie) { m.InWatchlist, m.IsFavorite, m.IsWatched = &no, &no, &no }, `"42-v7-l"`},
+		{"on the watchlist", func(m *models.Movie) { m.InWatchlist = &yes }, `"42-v7-lw"`},
+		{"favorite", func(m *models.Movie) { m.IsFavorite = &yes }, `"42-v7-lf"`},
+		{"watched", func(m *models.Movie) { m.IsWatched, m.WatchedAt = &yes, &watchedAt }, `"42-v7-l-s1792324800000"`},
+		{"everything", func(m *models.Movie) {
+			m.InWatchlist, m.IsFavorite, m.IsWatched, m.WatchedAt = &yes, &yes, &yes, &watchedAt
+		}, `"42-v7-lwf-s1792324800000"`},
+		{"translated", func(m *models.Movie) { m.Locale, m.InWatchlist = "id", &yes }, `"42-v7-id-lw"`},
+	}
+
+	seen := map[string]string{movieETag(&movie): "catalog"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := movie
+			tt.state(&m)
+			got := personalMovieETag(&m)
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+			if other, ok := seen[got]; ok {
+				t.Errorf("%s shares its ETag with %s", tt.name, other)
+			}
+			seen[got] = tt.name
+
+			// The personal tag is still accepted as If-Match for the movie version
+			app := fiber.New()
+			app.Put("/", func(c *fiber.Ctx) error {
+				if version := ifMatchVersion(c, m.ID); version != m.Version {
+					t.Errorf("If-Match %s: got version %d, want %d", got, version, m.Version)
+				}
+				return nil
+			})
+			req := httptest.NewRequest(fiber.MethodPut, "/", nil)
+			req.Header.Set(fiber.HeaderIfMatch, got)
+			if _, err := app.Test(req); err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+		})
+	}
+}
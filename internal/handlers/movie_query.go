@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"movie-backend/internal/models"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// movieFilterFromQuery reads the list filters shared by the movie list and
+// export endpoints. Values are copied so they stay valid after the handler
+// returns.
+func movieFilterFromQuery(c *fiber.Ctx) (models.MovieFilter, error) {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	genreID, _ := strconv.ParseUint(c.Query("genre_id", "0"), 10, 32)
+	tagID, _ := strconv.ParseUint(c.Query("tag_id", "0"), 10, 32)
+	keywordID, _ := strconv.ParseUint(c.Query("keyword_id", "0"), 10, 32)
+	providerID, _ := strconv.ParseUint(c.Query("provider", "0"), 10, 32)
+
+	filter := models.MovieFilter{
+		Page:       page,
+		Limit:      limit,
+		Search:     strings.Clone(c.Query("search", "")),
+		SortBy:     strings.Clone(c.Query("sort_by", "updated_at")),
+		Order:      strings.Clone(c.Query("order", "DESC")),
+		StartDate:  strings.Clone(c.Query("start_date", "")),
+		EndDate:    strings.Clone(c.Query("end_date", "")),
+		GenreID:    uint(genreID),
+		TagID:      uint(tagID),
+		KeywordID:  uint(keywordID),
+		ProviderID: uint(providerID),
+	}
+	if hasTrailer, err := strconv.ParseBool(c.Query("has_trailer")); err == nil {
+		filter.HasTrailer = &hasTrailer
+	}
+
+	region, err := regionFromQuery(c)
+	if err != nil {
+		return filter, err
+	}
+	filter.Region = strings.Clone(region)
+
+	if maxCertification := c.Query("max_certification", ""); maxCertification != "" {
+		if region == "" {
+			return filter, fmt.Errorf("max_certification requires region")
+		}
+		if _, ok := models.CertificationsUpTo(region, maxCertification); !ok {
+			return filter, fmt.Errorf("unknown certification %q for region %s", maxCertification, region)
+		}
+		filter.MaxCertification = strings.Clone(maxCertification)
+	}
+	return filter, nil
+}
+
+// regionFromQuery returns the upper-cased ISO 3166-1 country of the region
+// query parameter, or "" when it is absent
+func regionFromQuery(c *fiber.Ctx) (string, error) {
+	region := strings.ToUpper(strings.TrimSpace(c.Query("region", "")))
+	if region == "" {
+		return "", nil
+	}
+	if len(region) != 2 || region[0] < 'A' || region[0] > 'Z' || region[1] < 'A' || region[1] > 'Z' {
+		return "", fmt.Errorf("invalid region %q, use an ISO 3166-1 country code such as US", region)
+	}
+	return region, nil
+}
+
+// movieProjectionFromQuery parses the fields, expand and lang query
+// parameters. Without expand every relation but keywords and tags is loaded;
+// an empty expand loads none. Without lang the locales come from Accept-Language.
+func movieProjectionFromQuery(c *fiber.Ctx) (models.MovieProjection, error) {
+	var projection models.MovieProjection
+
+	locales, err := movieLocalesFromQuery(c)
+	if err != nil {
+		return projection, err
+	}
+	projection.Locales = locales
+
+	validFields := make(map[string]bool, len(models.MovieFieldColumns))
+	for _, column := range models.MovieFieldColumns {
+		validFields[column] = true
+	}
+	for _, field := range splitQueryList(c.Query("fields", "")) {
+		if !validFields[field] {
+			return projection, fmt.Errorf("unknown field %q", field)
+		}
+		projection.Fields = append(projection.Fields, field)
+	}
+
+	if c.Context().QueryArgs().Has("expand") {
+		projection.Expand = []string{}
+		for _, relation := range splitQueryList(c.Query("expand", "")) {
+			if relation == "none" {
+				continue
+			}
+			if _, ok := models.MovieExpandRelations[relation]; !ok {
+				return projection, fmt.Errorf("unknown relation %q, expand supports genres, language, collection, keywords and tags", relation)
+			}
+			projection.Expand = append(projection.Expand, relation)
+		}
+	}
+
+	return projection, nil
+}
+
+// movieLocalesFromQuery returns the preferred locales for translated movie
+// text, from the lang parameter or else the Accept-Language header
+func movieLocalesFromQuery(c *fiber.Ctx) ([]string, error) {
+	c.Vary(fiber.HeaderAcceptLanguage)
+
+	lang := c.Query("lang", "")
+	if lang == "" {
+		return utils.AcceptedLocales(c), nil
+	}
+
+	locale, ok := utils.NormalizeLocale(lang)
+	if !ok {
+		return nil, fmt.Errorf("invalid lang %q", lang)
+	}
+	if base := models.LocaleBase(locale); base != locale {
+		return []string{locale, base}, nil
+	}
+	return []string{locale}, nil
+}
+
+// projectMovie trims the movie JSON down to the requested fields and expanded
+// relations. Without a field selection the movie is returned as is.
+func projectMovie(movie *models.Movie, projection models.MovieProjection) (interface{}, error) {
+	if len(projection.Fields) == 0 {
+		return movie, nil
+	}
+
+	data, err := json.Marshal(movie)
+	if err != nil {
+		return nil, err
+	}
+	var full map[string]interface{}
+	if err := json.Unmarshal(data, &full); err != nil {
+		return nil, err
+	}
+
+	projected := make(map[string]interface{}, len(projection.Fields)+len(models.MovieExpandRelations))
+	for _, field := range projection.Fields {
+		projected[field] = full[field]
+	}
+	if movie.Locale != "" {
+		projected["locale"] = movie.Locale
+	}
+	for _, personal := range []string{"in_watchlist", "is_favorite", "is_watched", "watched_at"} {
+		if value, ok := full[personal]; ok {
+			projected[personal] = value
+		}
+	}
+	for relation := range models.MovieExpandRelations {
+		if value, ok := full[relation]; ok && projection.Expands(relation) {
+			projected[relation] = value
+		}
+	}
+	return projected, nil
+}
+
+func projectMovies(movies []models.Movie, projection models.MovieProjection) (interface{}, error) {
+	if len(projection.Fields) == 0 {
+		return movies, nil
+	}
+
+	projected := make([]interface{}, len(movies))
+	for i := range movies {
+		item, err := projectMovie(&movies[i], projection)
+		if err != nil {
+			return nil, err
+		}
+		projected[i] = item
+	}
+	return projected, nil
+}
+
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
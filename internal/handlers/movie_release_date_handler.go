@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"movie-backend/internal/repository"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetMovieReleaseDates godoc
+// @Summary Get movie release dates
+// @Description Get the release dates and age certifications of a movie per country, synced from TMDB. Types are 1 premiere, 2 limited theatrical, 3 theatrical, 4 digital, 5 physical and 6 TV.
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param region query string false "ISO 3166-1 country to return release dates for (e.g. US)"
+// @Success 200 {object} utils.StandardResponse "List of release dates"
+// @Failure 400 {object} utils.StandardResponse "Invalid movie ID or region"
+// @Failure 404 {object} utils.StandardResponse "Movie not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/{id}/release-dates [get]
+func (h *MovieHandler) GetMovieReleaseDates(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+	region, err := regionFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	releaseDates, err := h.service.GetMovieReleaseDates(c.Context(), uint(id), region)
+	if err != nil {
+		if errors.Is(err, repository.ErrMovieNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Movie not found")
+		}
+		h.logger.WithError(err).WithField("id", id).Error("Failed to get movie release dates")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve release dates")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Release dates retrieved successfully", releaseDates)
+}
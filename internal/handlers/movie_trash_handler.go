@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"movie-backend/internal/services"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetTrashedMovies godoc
+// @Summary Get deleted movies
+// @Description Get movies in the trash. They can be restored until they are purged after the retention window.
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Param search query string false "Search by title, translated title or overview"
+// @Param sort_by query string false "Sort by field (id, title, release_date, vote_average, user_rating, popularity, created_at, updated_at, deleted_at)" default(deleted_at)
+// @Param order query string false "Sort order (ASC/DESC)" default(DESC)
+// @Param fields query string false "Comma separated fields to return (e.g. id,title,poster_path)"
+// @Param expand query string false "Comma separated relations to load (genres, language, collection, keywords, tags). Keywords and tags are only loaded when listed; the others are loaded when omitted"
+// @Success 200 {object} utils.StandardResponse "List of deleted movies"
+// @Failure 400 {object} utils.StandardResponse "Invalid filters, fields or expand"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/trash [get]
+func (h *MovieHandler) GetTrashedMovies(c *fiber.Ctx) error {
+	filter, err := movieFilterFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+	if c.Query("sort_by") == "" {
+		filter.SortBy = "deleted_at"
+	}
+	projection, err := movieProjectionFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if h.catalogNotModified(c) {
+		return utils.NotModifiedResponse(c)
+	}
+
+	movies, total, err := h.service.GetTrashedMovies(c.Context(), filter, projection)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get trashed movies")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve deleted movies")
+	}
+
+	data, err := projectMovies(movies, projection)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to project movies")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve deleted movies")
+	}
+
+	meta := utils.CreatePaginationMeta(filter.Page, filter.Limit, total)
+	return utils.SuccessWithMetaResponse(c, fiber.StatusOK, "Deleted movies retrieved successfully", data, meta)
+}
+
+// RestoreMovie godoc
+// @Summary Restore a deleted movie
+// @Description Take a movie out of the trash
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param id path int true "Movie ID"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Movie restored successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid movie ID"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Movie not found in trash"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/{id}/restore [post]
+func (h *MovieHandler) RestoreMovie(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+
+	movie, err := h.service.RestoreMovie(auditContext(c), uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrMovieNotInTrash) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Movie not found in trash")
+		}
+		h.logger.WithError(err).WithField("id", id).Error("Failed to restore movie")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to restore movie")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Movie restored successfully", movie)
+}
+
+// PurgeTrash godoc
+// @Summary Purge the trash
+// @Description Permanently delete movies that have been in the trash longer than the retention window, with their images. This also runs periodically in the background.
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Trash purged successfully"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the admin role"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/trash/purge [post]
+func (h *MovieHandler) PurgeTrash(c *fiber.Ctx) error {
+	purged, err := h.service.PurgeTrash(c.Context())
+	if err != nil {
+		h.logger.WithError(err).WithField("purged", purged).Error("Failed to purge trash")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to purge trash")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Trash purged successfully", fiber.Map{"purged": purged})
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"movie-backend/internal/repository"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetMovieVideos godoc
+// @Summary Get movie videos
+// @Description Get the trailers, teasers, clips and other videos of a movie synced from TMDB, official and newest first
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param type query string false "Comma separated video types (e.g. Trailer,Teaser)"
+// @Success 200 {object} utils.StandardResponse "List of videos"
+// @Failure 400 {object} utils.StandardResponse "Invalid movie ID"
+// @Failure 404 {object} utils.StandardResponse "Movie not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/{id}/videos [get]
+func (h *MovieHandler) GetMovieVideos(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+
+	videos, err := h.service.GetMovieVideos(c.Context(), uint(id), splitQueryList(c.Query("type", "")))
+	if err != nil {
+		if errors.Is(err, repository.ErrMovieNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Movie not found")
+		}
+		h.logger.WithError(err).WithField("id", id).Error("Failed to get movie videos")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve videos")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Videos retrieved successfully", videos)
+}
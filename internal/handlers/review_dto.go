@@ -0,0 +1,12 @@
+package handlers
+
+type ReviewRequest struct {
+	Rating int    `json:"rating" example:"8"` // 1 to 10
+	Title  string `json:"title" example:"Still holds up"`
+	Body   string `json:"body" example:"The twist works even on a rewatch."`
+}
+
+type ReviewModerationRequest struct {
+	Status string `json:"status" example:"approved"` // pending, approved or rejected
+	Note   string `json:"note" example:"Contains spoilers"`
+}
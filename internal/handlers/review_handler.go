@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"movie-backend/internal/middleware"
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+	"movie-backend/internal/services"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func reviewPageFromQuery(c *fiber.Ctx) (int, int) {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	return page, limit
+}
+
+// GetMovieReviews godoc
+// @Summary Get movie reviews
+// @Description Get the approved user reviews of a movie, newest first. The movie's user_rating_average, user_rating_count and user_rating_histogram summarize them.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} utils.StandardResponse "List of reviews"
+// @Failure 400 {object} utils.StandardResponse "Invalid movie ID"
+// @Failure 404 {object} utils.StandardResponse "Movie not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/{id}/reviews [get]
+func (h *MovieHandler) GetMovieReviews(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+
+	page, limit := reviewPageFromQuery(c)
+	reviews, total, err := h.service.GetMovieReviews(c.Context(), uint(id), page, limit)
+	if err != nil {
+		return h.reviewErrorResponse(c, err, "Failed to retrieve reviews")
+	}
+
+	meta := utils.CreatePaginationMeta(page, limit, total)
+	return utils.SuccessWithMetaResponse(c, fiber.StatusOK, "Reviews retrieved successfully", reviews, meta)
+}
+
+// GetUserReviews godoc
+// @Summary Get my reviews
+// @Description Get the caller's ratings and reviews in every moderation state, newest first
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} utils.StandardResponse "List of reviews"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Not available to API keys"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /me/reviews [get]
+func (h *MovieHandler) GetUserReviews(c *fiber.Ctx) error {
+	page, limit := reviewPageFromQuery(c)
+	reviews, total, err := h.service.GetUserReviews(c.Context(), personalUserID(c), page, limit)
+	if err != nil {
+		return h.reviewErrorResponse(c, err, "Failed to retrieve reviews")
+	}
+
+	meta := utils.CreatePaginationMeta(page, limit, total)
+	return utils.SuccessWithMetaResponse(c, fiber.StatusOK, "Reviews retrieved successfully", reviews, meta)
+}
+
+// GetUserReview godoc
+// @Summary Get my review of a movie
+// @Description Get the caller's rating and review of a movie with its moderation state
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param movieId path int true "Movie ID"
+// @Success 200 {object} utils.StandardResponse "The review"
+// @Failure 400 {object} utils.StandardResponse "Invalid movie ID"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Not available to API keys"
+// @Failure 404 {object} utils.StandardResponse "Review not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /me/reviews/{movieId} [get]
+func (h *MovieHandler) GetUserReview(c *fiber.Ctx) error {
+	movieID, err := strconv.ParseUint(c.Params("movieId"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+
+	review, err := h.service.GetUserReview(c.Context(), personalUserID(c), uint(movieID))
+	if err != nil {
+		return h.reviewErrorResponse(c, err, "Failed to retrieve review")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Review retrieved successfully", review)
+}
+
+// ReviewMovie godoc
+// @Summary Rate and review a movie
+// @Description Save the caller's rating from 1 to 10 of a movie, with an optional title and body, replacing an earlier one. A rating without text counts towards the movie's user rating right away; a review with text is pending until an editor approves it. Changing only the rating keeps the moderation state.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param movieId path int true "Movie ID"
+// @Param review body ReviewRequest true "Rating and review"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "The saved review"
+// @Failure 400 {object} utils.StandardResponse "Invalid movie ID, rating or title"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Not available to API keys"
+// @Failure 404 {object} utils.StandardResponse "Movie not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /me/reviews/{movieId} [put]
+func (h *MovieHandler) ReviewMovie(c *fiber.Ctx) error {
+	movieID, err := strconv.ParseUint(c.Params("movieId"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+
+	var req ReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	review, err := h.service.ReviewMovie(c.Context(), personalUserID(c), uint(movieID), req.Rating, req.Title, req.Body)
+	if err != nil {
+		return h.reviewErrorResponse(c, err, "Failed to save review")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Review saved successfully", review)
+}
+
+// DeleteUserReview godoc
+// @Summary Delete my review of a movie
+// @Description Remove the caller's rating and review of a movie
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param movieId path int true "Movie ID"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Review deleted"
+// @Failure 400 {object} utils.StandardResponse "Invalid movie ID"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Not available to API keys"
+// @Failure 404 {object} utils.StandardResponse "Review not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /me/reviews/{movieId} [delete]
+func (h *MovieHandler) DeleteUserReview(c *fiber.Ctx) error {
+	movieID, err := strconv.ParseUint(c.Params("movieId"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+
+	if err := h.service.DeleteUserReview(c.Context(), personalUserID(c), uint(movieID)); err != nil {
+		return h.reviewErrorResponse(c, err, "Failed to delete review")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Review deleted successfully", nil)
+}
+
+// GetReviews godoc
+// @Summary Get reviews for moderation
+// @Description Get user reviews by moderation state, newest first. Pending reviews are listed by default.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Moderation state (pending, approved, rejected)" default(pending)
+// @Param movie_id query int false "Only reviews of this movie"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} utils.StandardResponse "List of reviews"
+// @Failure 400 {object} utils.StandardResponse "Invalid status or movie ID"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /reviews [get]
+func (h *MovieHandler) GetReviews(c *fiber.Ctx) error {
+	filter := models.ReviewFilter{Status: c.Query("status", models.ReviewStatusPending)}
+	if movieID := c.Query("movie_id"); movieID != "" {
+		id, err := strconv.ParseUint(movieID, 10, 32)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+		}
+		filter.MovieID = uint(id)
+	}
+	filter.Page, filter.Limit = reviewPageFromQuery(c)
+
+	reviews, total, err := h.service.GetReviews(c.Context(), filter)
+	if err != nil {
+		return h.reviewErrorResponse(c, err, "Failed to retrieve reviews")
+	}
+
+	meta := utils.CreatePaginationMeta(filter.Page, filter.Limit, total)
+	return utils.SuccessWithMetaResponse(c, fiber.StatusOK, "Reviews retrieved successfully", reviews, meta)
+}
+
+// ModerateReview godoc
+// @Summary Moderate a review
+// @Description Approve or reject a user review, or put it back in the queue with pending. Only approved reviews are shown on the movie and count towards its user rating.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Review ID"
+// @Param moderation body ReviewModerationRequest true "New moderation state"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "The moderated review"
+// @Failure 400 {object} utils.StandardResponse "Invalid review ID or status"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Review not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /reviews/{id}/moderation [put]
+func (h *MovieHandler) ModerateReview(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid review ID")
+	}
+
+	var req ReviewModerationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	identity := middleware.IdentityFrom(c)
+	if identity == nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Authentication required")
+	}
+
+	review, err := h.service.ModerateReview(c.Context(), uint(id), identity.UserID, req.Status, req.Note)
+	if err != nil {
+		return h.reviewErrorResponse(c, err, "Failed to moderate review")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Review moderated successfully", review)
+}
+
+func (h *MovieHandler) reviewErrorResponse(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, repository.ErrMovieNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Movie not found")
+	case errors.Is(err, services.ErrReviewNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Review not found")
+	case errors.Is(err, services.ErrInvalidRating), errors.Is(err, services.ErrReviewTitleTooLong),
+		errors.Is(err, services.ErrInvalidReviewStatus):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	h.logger.WithError(err).Error(message)
+	return utils.ErrorResponse(c, fiber.StatusInternalServerError, message)
+}
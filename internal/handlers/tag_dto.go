@@ -0,0 +1,10 @@
+package handlers
+
+type TagRequest struct {
+	Name        string `json:"name" example:"Staff Picks"`
+	Description string `json:"description" example:"Favourites of the editorial team"`
+}
+
+type MovieTagsRequest struct {
+	TagIDs []uint `json:"tag_ids" example:"1,2"`
+}
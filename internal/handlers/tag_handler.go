@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"movie-backend/internal/repository"
+	"movie-backend/internal/services"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetTags godoc
+// @Summary Get all tags
+// @Description Get all editor tags with the number of movies carrying each tag
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Success 200 {object} utils.StandardResponse "List of tags"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /tags [get]
+func (h *MovieHandler) GetTags(c *fiber.Ctx) error {
+	tags, err := h.service.GetTags(c.Context())
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get tags")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve tags")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Tags retrieved successfully", tags)
+}
+
+// GetTagCloud godoc
+// @Summary Get the tag cloud
+// @Description Get the most used editor tags and TMDB keywords with their movie counts, most used first
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param type query string false "tag, keyword or all" default(all)
+// @Param limit query int false "Maximum number of entries (1-200)" default(50)
+// @Success 200 {object} utils.StandardResponse "Tag cloud"
+// @Failure 400 {object} utils.StandardResponse "Invalid type"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /tags/cloud [get]
+func (h *MovieHandler) GetTagCloud(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "0"))
+
+	if h.catalogNotModified(c) {
+		return utils.NotModifiedResponse(c)
+	}
+
+	entries, err := h.service.GetTagCloud(c.Context(), c.Query("type", "all"), limit)
+	if err != nil {
+		return h.tagErrorResponse(c, err, "Failed to retrieve tag cloud")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Tag cloud retrieved successfully", entries)
+}
+
+// GetTag godoc
+// @Summary Get a tag
+// @Description Get an editor tag with its movie count
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Success 200 {object} utils.StandardResponse "Tag details"
+// @Failure 400 {object} utils.StandardResponse "Invalid tag ID"
+// @Failure 404 {object} utils.StandardResponse "Tag not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /tags/{id} [get]
+func (h *MovieHandler) GetTag(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid tag ID")
+	}
+
+	tag, err := h.service.GetTag(c.Context(), uint(id))
+	if err != nil {
+		return h.tagErrorResponse(c, err, "Failed to retrieve tag")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Tag retrieved successfully", tag)
+}
+
+// CreateTag godoc
+// @Summary Create a tag
+// @Description Create an editor tag for curating movies
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param tag body TagRequest true "Tag request object"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 201 {object} utils.StandardResponse "Tag created successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid request body"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 409 {object} utils.StandardResponse "Tag name already exists"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /tags [post]
+func (h *MovieHandler) CreateTag(c *fiber.Ctx) error {
+	var req TagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tag, err := h.service.CreateTag(c.Context(), req.Name, req.Description)
+	if err != nil {
+		return h.tagErrorResponse(c, err, "Failed to create tag")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Tag created successfully", tag)
+}
+
+// UpdateTag godoc
+// @Summary Update a tag
+// @Description Change the name and description of an editor tag
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param id path int true "Tag ID"
+// @Param tag body TagRequest true "Tag request object"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Tag updated successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid request"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Tag not found"
+// @Failure 409 {object} utils.StandardResponse "Tag name already exists"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /tags/{id} [put]
+func (h *MovieHandler) UpdateTag(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid tag ID")
+	}
+
+	var req TagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tag, err := h.service.UpdateTag(c.Context(), uint(id), req.Name, req.Description)
+	if err != nil {
+		return h.tagErrorResponse(c, err, "Failed to update tag")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Tag updated successfully", tag)
+}
+
+// DeleteTag godoc
+// @Summary Delete a tag
+// @Description Remove an editor tag from every movie and delete it
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param id path int true "Tag ID"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Tag deleted successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid tag ID"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Tag not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /tags/{id} [delete]
+func (h *MovieHandler) DeleteTag(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid tag ID")
+	}
+
+	if err := h.service.DeleteTag(c.Context(), uint(id)); err != nil {
+		return h.tagErrorResponse(c, err, "Failed to delete tag")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Tag deleted successfully", nil)
+}
+
+// TagMovie godoc
+// @Summary Tag a movie
+// @Description Add editor tags to a movie. Tags the movie already has are kept once.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param id path int true "Movie ID"
+// @Param tags body MovieTagsRequest true "Tags to add"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "All tags of the movie"
+// @Failure 400 {object} utils.StandardResponse "Invalid request"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Movie or tag not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/{id}/tags [post]
+func (h *MovieHandler) TagMovie(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+
+	var req MovieTagsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tags, err := h.service.TagMovie(c.Context(), uint(id), req.TagIDs)
+	if err != nil {
+		return h.tagErrorResponse(c, err, "Failed to tag movie")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Movie tagged successfully", tags)
+}
+
+// UntagMovie godoc
+// @Summary Remove a tag from a movie
+// @Description Remove an editor tag from a movie
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param id path int true "Movie ID"
+// @Param tagId path int true "Tag ID"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "Tag removed successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid movie or tag ID"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
+// @Failure 404 {object} utils.StandardResponse "Movie not found or movie does not have the tag"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/{id}/tags/{tagId} [delete]
+func (h *MovieHandler) UntagMovie(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+	tagID, err := strconv.ParseUint(c.Params("tagId"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid tag ID")
+	}
+
+	if err := h.service.UntagMovie(c.Context(), uint(id), uint(tagID)); err != nil {
+		return h.tagErrorResponse(c, err, "Failed to remove tag")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Tag removed successfully", nil)
+}
+
+func (h *MovieHandler) tagErrorResponse(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, repository.ErrMovieNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Movie not found")
+	case errors.Is(err, services.ErrTagNotFound), errors.Is(err, services.ErrMovieTagNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrTagNameExists):
+		return utils.ErrorResponse(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrTagNameRequired), errors.Is(err, services.ErrTagIDsRequired),
+		errors.Is(err, services.ErrInvalidTagCloudType):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	h.logger.WithError(err).Error(message)
+	return utils.ErrorResponse(c, fiber.StatusInternalServerError, message)
+}
@@ -26,10 +26,14 @@ func NewUploadHandler(minioService *services.MinIOService, logger *logrus.Logger
 // @Tags Upload
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Param filename query string true "Filename"
 // @Param contentType query string false "Content Type" default(image/jpeg)
 // @Success 200 {object} utils.StandardResponse
 // @Failure 400 {object} utils.StandardResponse
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the editor role"
 // @Failure 500 {object} utils.StandardResponse
 // @Router /upload/presign [get]
 func (h *UploadHandler) GetPresignedURL(c *fiber.Ctx) error {
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"strconv"
+
+	"movie-backend/internal/middleware"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetUsers godoc
+// @Summary Get users
+// @Description Get the user accounts with their roles, by email
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} utils.StandardResponse "List of users"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the admin role"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /users [get]
+func (h *AuthHandler) GetUsers(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	users, total, err := h.service.GetUsers(c.Context(), page, limit)
+	if err != nil {
+		return h.authErrorResponse(c, err, "Failed to retrieve users")
+	}
+
+	meta := utils.CreatePaginationMeta(page, limit, total)
+	return utils.SuccessWithMetaResponse(c, fiber.StatusOK, "Users retrieved successfully", users, meta)
+}
+
+// SetUserRole godoc
+// @Summary Change the role of a user
+// @Description Make a user a viewer, editor or admin. The new role applies from the user's next login or token refresh. Admins cannot change their own role.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param role body UserRoleRequest true "Role request object"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "User role updated successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid user ID or role"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the admin role"
+// @Failure 404 {object} utils.StandardResponse "User not found"
+// @Failure 409 {object} utils.StandardResponse "Admins cannot change their own role"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /users/{id}/role [put]
+func (h *AuthHandler) SetUserRole(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	var req UserRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	user, err := h.service.SetUserRole(c.Context(), middleware.IdentityFrom(c).UserID, uint(id), req.Role)
+	if err != nil {
+		return h.authErrorResponse(c, err, "Failed to update user role")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "User role updated successfully", user)
+}
+
+// DeleteUser godoc
+// @Summary Delete a user
+// @Description Delete a user account and revoke its refresh tokens. Admins cannot delete their own account.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "User deleted successfully"
+// @Failure 400 {object} utils.StandardResponse "Invalid user ID"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Requires the admin role"
+// @Failure 404 {object} utils.StandardResponse "User not found"
+// @Failure 409 {object} utils.StandardResponse "Admins cannot delete their own account"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /users/{id} [delete]
+func (h *AuthHandler) DeleteUser(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	if err := h.service.DeleteUser(c.Context(), middleware.IdentityFrom(c).UserID, uint(id)); err != nil {
+		return h.authErrorResponse(c, err, "Failed to delete user")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "User deleted successfully", nil)
+}
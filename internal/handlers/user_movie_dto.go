@@ -0,0 +1,7 @@
+package handlers
+
+import "time"
+
+type UserMovieRequest struct {
+	WatchedAt *time.Time `json:"watched_at" example:"2024-05-01T20:00:00Z"` // defaults to now
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"movie-backend/internal/middleware"
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+	"movie-backend/internal/services"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// personalUserID returns the signed-in user whose lists movie responses are
+// annotated with, or 0 for anonymous callers and API keys
+func personalUserID(c *fiber.Ctx) uint {
+	identity := middleware.IdentityFrom(c)
+	if identity == nil || identity.APIKey != nil {
+		return 0
+	}
+	return identity.UserID
+}
+
+// annotateUserMovies sets the caller's in_watchlist, is_favorite and watched
+// flags on the movies. Personal responses must not be served from shared
+// caches or revalidated against the catalog version, which ignores the lists.
+func (h *MovieHandler) annotateUserMovies(c *fiber.Ctx, movies []models.Movie) error {
+	userID := personalUserID(c)
+	if userID == 0 {
+		return nil
+	}
+	c.Vary(fiber.HeaderAuthorization)
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+	return h.service.AnnotateUserMovies(c.Context(), userID, movies)
+}
+
+// GetUserMovies godoc
+// @Summary Get a personal movie list
+// @Description Get the movies on the caller's watchlist, favorites or watched list, with the same filters, sorting and projection as the movie list
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param list path string true "List (watchlist, favorites, watched)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Param search query string false "Search by title, translated title or overview"
+// @Param sort_by query string false "Sort by field (id, title, release_date, vote_average, user_rating, popularity, created_at, updated_at)" default(updated_at)
+// @Param order query string false "Sort order (ASC/DESC)" default(DESC)
+// @Param start_date query string false "Filter by start date (YYYY-MM-DD)"
+// @Param end_date query string false "Filter by end date (YYYY-MM-DD)"
+// @Param region query string false "ISO 3166-1 country; date filters use the release dates in this country"
+// @Param max_certification query string false "Most restrictive age certification in region (e.g. PG-13), requires region"
+// @Param genre_id query int false "Filter by genre ID"
+// @Param tag_id query int false "Filter by editor tag ID"
+// @Param keyword_id query int false "Filter by TMDB keyword ID"
+// @Param has_trailer query bool false "Only movies with (true) or without (false) a trailer"
+// @Param provider query int false "Only movies available with this watch provider ID, in region when given"
+// @Param fields query string false "Comma separated fields to return (e.g. id,title,poster_path)"
+// @Param expand query string false "Comma separated relations to load (genres, language, collection, keywords, tags). Keywords and tags are only loaded when listed; the others are loaded when omitted"
+// @Param lang query string false "Locale for title, overview and tagline (e.g. id). Overrides Accept-Language"
+// @Success 200 {object} utils.StandardResponse "List of movies"
+// @Failure 400 {object} utils.StandardResponse "Invalid list, filters, fields or expand"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Not available to API keys"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /me/{list} [get]
+func (h *MovieHandler) GetUserMovies(c *fiber.Ctx) error {
+	filter, err := movieFilterFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+	projection, err := movieProjectionFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	c.Vary(fiber.HeaderAuthorization)
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+	movies, total, err := h.service.GetUserMovies(c.Context(), personalUserID(c), c.Params("list"), filter, projection)
+	if err != nil {
+		return h.userMovieErrorResponse(c, err, "Failed to retrieve movies")
+	}
+
+	data, err := projectMovies(movies, projection)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to project movies")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve movies")
+	}
+
+	meta := utils.CreatePaginationMeta(filter.Page, filter.Limit, total)
+	return utils.SuccessWithMetaResponse(c, fiber.StatusOK, "Movies retrieved successfully", data, meta)
+}
+
+// AddUserMovie godoc
+// @Summary Add a movie to a personal list
+// @Description Put a movie on the caller's watchlist, favorites or watched list. For the watched list the body may give watched_at, which defaults to now; adding a watched movie again updates the date.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param list path string true "List (watchlist, favorites, watched)"
+// @Param movieId path int true "Movie ID"
+// @Param watched body UserMovieRequest false "Watched date, for the watched list"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "The caller's state of the movie"
+// @Failure 400 {object} utils.StandardResponse "Invalid list, movie ID or watched_at"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Not available to API keys"
+// @Failure 404 {object} utils.StandardResponse "Movie not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /me/{list}/{movieId} [put]
+func (h *MovieHandler) AddUserMovie(c *fiber.Ctx) error {
+	movieID, err := strconv.ParseUint(c.Params("movieId"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+
+	var req UserMovieRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	state, err := h.service.AddUserMovie(c.Context(), personalUserID(c), uint(movieID), c.Params("list"), req.WatchedAt)
+	if err != nil {
+		return h.userMovieErrorResponse(c, err, "Failed to add movie to list")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Movie added to list successfully", state)
+}
+
+// RemoveUserMovie godoc
+// @Summary Remove a movie from a personal list
+// @Description Take a movie off the caller's watchlist, favorites or watched list. Removing a movie that is not on the list succeeds.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param list path string true "List (watchlist, favorites, watched)"
+// @Param movieId path int true "Movie ID"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Success 200 {object} utils.StandardResponse "The caller's state of the movie"
+// @Failure 400 {object} utils.StandardResponse "Invalid list or movie ID"
+// @Failure 401 {object} utils.StandardResponse "Authentication required"
+// @Failure 403 {object} utils.StandardResponse "Not available to API keys"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /me/{list}/{movieId} [delete]
+func (h *MovieHandler) RemoveUserMovie(c *fiber.Ctx) error {
+	movieID, err := strconv.ParseUint(c.Params("movieId"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+
+	state, err := h.service.RemoveUserMovie(c.Context(), personalUserID(c), uint(movieID), c.Params("list"))
+	if err != nil {
+		return h.userMovieErrorResponse(c, err, "Failed to remove movie from list")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Movie removed from list successfully", state)
+}
+
+func (h *MovieHandler) userMovieErrorResponse(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, repository.ErrMovieNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Movie not found")
+	case errors.Is(err, services.ErrUnknownUserList), errors.Is(err, services.ErrWatchedInFuture):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	h.logger.WithError(err).Error(message)
+	return utils.ErrorResponse(c, fiber.StatusInternalServerError, message)
+}
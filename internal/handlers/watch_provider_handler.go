@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"movie-backend/internal/repository"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetMovieProviders godoc
+// @Summary Get where to watch a movie
+// @Description Get the streaming (flatrate), rental and purchase providers of a movie per region, synced from TMDB
+// @Tags providers
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param region query string false "ISO 3166-1 country to return providers for (e.g. US)"
+// @Success 200 {object} utils.StandardResponse "Providers per region"
+// @Failure 400 {object} utils.StandardResponse "Invalid movie ID or region"
+// @Failure 404 {object} utils.StandardResponse "Movie not found"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /movies/{id}/providers [get]
+func (h *MovieHandler) GetMovieProviders(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid movie ID")
+	}
+	region, err := regionFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	providers, err := h.service.GetMovieProviders(c.Context(), uint(id), region)
+	if err != nil {
+		if errors.Is(err, repository.ErrMovieNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Movie not found")
+		}
+		h.logger.WithError(err).WithField("id", id).Error("Failed to get movie providers")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve providers")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Providers retrieved successfully", providers)
+}
+
+// GetProviders godoc
+// @Summary Get watch providers
+// @Description Get every watch provider with the number and share of catalog movies available with it, most available first. Use the IDs with the provider filter of the movie list.
+// @Tags providers
+// @Accept json
+// @Produce json
+// @Param region query string false "ISO 3166-1 country to count availability in; all regions when omitted"
+// @Success 200 {object} utils.StandardResponse "List of providers"
+// @Failure 400 {object} utils.StandardResponse "Invalid region"
+// @Failure 500 {object} utils.StandardResponse "Internal server error"
+// @Router /providers [get]
+func (h *MovieHandler) GetProviders(c *fiber.Ctx) error {
+	region, err := regionFromQuery(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if h.catalogNotModified(c) {
+		return utils.NotModifiedResponse(c)
+	}
+
+	providers, err := h.service.GetProviders(c.Context(), region)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get providers")
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve providers")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Providers retrieved successfully", providers)
+}
@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"movie-backend/internal/audit"
+	"movie-backend/internal/models"
+	"movie-backend/internal/services"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LocalsIdentityKey is the fiber.Ctx Locals key holding the *models.Identity
+// of the authenticated caller
+const LocalsIdentityKey = "auth_identity"
+
+// HeaderAPIKey is the request header carrying an API key
+const HeaderAPIKey = "X-API-Key"
+
+// Authenticate resolves the caller from an "Authorization: Bearer" access
+// token or an X-API-Key header. Requests without either continue anonymously;
+// requests with an invalid or expired one are rejected with 401, so clients
+// learn to refresh.
+func Authenticate(auth services.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		header := c.Get(fiber.HeaderAuthorization)
+		apiKey := c.Get(HeaderAPIKey)
+
+		var identity *models.Identity
+		switch {
+		case header != "" && apiKey != "":
+			return unauthorized(c, "Send either an Authorization header or an X-API-Key header, not both")
+		case header != "":
+			scheme, token, ok := strings.Cut(header, " ")
+			if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+				return unauthorized(c, "Authorization header must be Bearer <access token>")
+			}
+			verified, err := auth.VerifyAccessToken(strings.TrimSpace(token))
+			if err != nil {
+				return unauthorized(c, err.Error())
+			}
+			identity = verified
+		case apiKey != "":
+			verified, err := auth.VerifyAPIKey(c.Context(), apiKey)
+			if errors.Is(err, services.ErrInvalidAPIKey) {
+				return unauthorized(c, err.Error())
+			}
+			if err != nil {
+				return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to verify API key")
+			}
+			identity = verified
+		default:
+			return c.Next()
+		}
+
+		c.Locals(LocalsIdentityKey, identity)
+		c.Locals(audit.LocalsActorKey, identity.Name())
+		c.Locals(audit.LocalsActorRoleKey, identity.Role)
+		return c.Next()
+	}
+}
+
+// RequireAuth rejects requests without an authenticated caller
+func RequireAuth() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if IdentityFrom(c) == nil {
+			return unauthorized(c, "Authentication required")
+		}
+		return c.Next()
+	}
+}
+
+// AccessPolicy is the minimum role for reading (GET, HEAD, OPTIONS) and for
+// writing the routes of a group. An empty role allows anonymous callers.
+// API keys are checked against the scopes instead; a key can't call routes
+// that need a role but have no scope.
+type AccessPolicy struct {
+	Read       string
+	Write      string
+	ReadScope  string
+	WriteScope string
+}
+
+// Authorize enforces per group access policies, keyed by the route path below
+// base, e.g. "/movies". Paths are matched the way the router matches them, so
+// "/Movies" and "/movies/" fall under "/movies" unless routing is case
+// sensitive or strict. A ":name" segment in a key matches any one segment,
+// e.g. "/movies/:id/history". The policy with the longest matching key applies;
+// paths without a policy require an admin. Anonymous callers get a 401,
+// callers whose role or scopes are too low a 403.
+func Authorize(base string, policies map[string]AccessPolicy) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		path := routePath(c, base)
+
+		policy := AccessPolicy{Read: models.RoleAdmin, Write: models.RoleAdmin}
+		matched := -1
+		for prefix, p := range policies {
+			if len(prefix) > matched && policyCovers(prefix, path) {
+				policy, matched = p, len(prefix)
+			}
+		}
+
+		required, scope := policy.Write, policy.WriteScope
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+			required, scope = policy.Read, policy.ReadScope
+		}
+
+		identity := IdentityFrom(c)
+		if identity != nil && identity.APIKey != nil {
+			return authorizeAPIKey(c, identity.APIKey, required, scope)
+		}
+		if required == "" {
+			return c.Next()
+		}
+		if identity == nil {
+			return unauthorized(c, "Authentication required")
+		}
+		if !models.RoleAllows(identity.Role, required) {
+			return utils.ErrorWithDataResponse(c, fiber.StatusForbidden,
+				fmt.Sprintf("This action requires the %s role; your role is %s", required, identity.Role),
+				fiber.Map{"required_role": required, "role": identity.Role})
+		}
+		return c.Next()
+	}
+}
+
+// policyCovers reports whether path is the policy key or below it
+func policyCovers(key, path string) bool {
+	if !strings.Contains(key, "/:") {
+		return path == key || strings.HasPrefix(path, key+"/")
+	}
+	want, got := strings.Split(key, "/"), strings.Split(path, "/")
+	if len(got) < len(want) {
+		return false
+	}
+	for i, segment := range want {
+		if segment != got[i] && !strings.HasPrefix(segment, ":") {
+			return false
+		}
+	}
+	return true
+}
+
+func authorizeAPIKey(c *fiber.Ctx, key *models.APIKey, required, scope string) error {
+	switch {
+	case scope != "" && key.HasScope(scope):
+		return c.Next()
+	case scope != "":
+		return utils.ErrorWithDataResponse(c, fiber.StatusForbidden,
+			fmt.Sprintf("This action requires an API key with the %s scope", scope),
+			fiber.Map{"required_scope": scope, "scopes": key.Scopes})
+	case required == "":
+		return c.Next()
+	}
+	return utils.ErrorResponse(c, fiber.StatusForbidden, "This action is not available to API keys")
+}
+
+// routePath returns the request path below base, normalized like the router
+// normalizes it before matching routes
+func routePath(c *fiber.Ctx, base string) string {
+	path := c.Path()
+	cfg := c.App().Config()
+	if !cfg.CaseSensitive {
+		path, base = strings.ToLower(path), strings.ToLower(base)
+	}
+	if !cfg.StrictRouting && len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+	return strings.TrimPrefix(path, base)
+}
+
+// underPath reports whether the request path is prefix or below it, matched
+// like the router matches routes
+func underPath(c *fiber.Ctx, prefix string) bool {
+	path := routePath(c, "")
+	if !c.App().Config().CaseSensitive {
+		prefix = strings.ToLower(prefix)
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// IdentityFrom returns the authenticated caller of the request, or nil
+func IdentityFrom(c *fiber.Ctx) *models.Identity {
+	identity, _ := c.Locals(LocalsIdentityKey).(*models.Identity)
+	return identity
+}
+
+func unauthorized(c *fiber.Ctx, message string) error {
+	c.Set(fiber.HeaderWWWAuthenticate, `Bearer realm="movie-backend"`)
+	return utils.ErrorResponse(c, fiber.StatusUnauthorized, message)
+}
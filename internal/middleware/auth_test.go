@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"movie-backend/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var testPolicies = map[string]AccessPolicy{
+	"/auth":               {},
+	"/movies":             {Write: models.RoleEditor, ReadScope: models.ScopeMoviesRead, WriteScope: models.ScopeMoviesWrite},
+	"/movies/purge":       {Write: models.RoleAdmin},
+	"/movies/:id/history": {Read: models.RoleEditor, Write: models.RoleEditor},
+	"/users":              {Read: models.RoleAdmin, Write: models.RoleAdmin},
+}
+
+// newAuthorizeApp serves every path with a 200 behind Authorize. The caller
+// is taken from the X-Test-Role and X-Test-Scopes headers.
+func newAuthorizeApp(cfg fiber.Config) *fiber.App {
+	app := fiber.New(cfg)
+	v1 := app.Group("/api/v1")
+	v1.Use(func(c *fiber.Ctx) error {
+		if role := c.Get("X-Test-Role"); role != "" {
+			c.Locals(LocalsIdentityKey, &models.Identity{UserID: 1, Email: "test@example.com", Role: role})
+		}
+		if scopes := c.Get("X-Test-Scopes"); scopes != "" {
+			key := &models.APIKey{Name: "test", Scopes: strings.Split(scopes, ",")}
+			c.Locals(LocalsIdentityKey, &models.Identity{APIKey: key})
+		}
+		return c.Next()
+	})
+	v1.Use(Authorize("/api/v1", testPolicies))
+	v1.All("/*", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		role   string
+		scopes string
+		want   int
+	}{
+		{"anonymous read of open group", fiber.MethodGet, "/api/v1/movies", "", "", fiber.StatusOK},
+		{"anonymous read below open group", fiber.MethodGet, "/api/v1/movies/1", "", "", fiber.StatusOK},
+		{"anonymous write", fiber.MethodPost, "/api/v1/movies", "", "", fiber.StatusUnauthorized},
+		{"viewer write", fiber.MethodPost, "/api/v1/movies", models.RoleViewer, "", fiber.StatusForbidden},
+		{"editor write", fiber.MethodPost, "/api/v1/movies", models.RoleEditor, "", fiber.StatusOK},
+		{"editor write to longer admin prefix", fiber.MethodPost, "/api/v1/movies/purge", models.RoleEditor, "", fiber.StatusForbidden},
+		{"admin write to longer admin prefix", fiber.MethodPost, "/api/v1/movies/purge", models.RoleAdmin, "", fiber.StatusOK},
+		{"parameter segment", fiber.MethodGet, "/api/v1/movies/42/history", "", "", fiber.StatusUnauthorized},
+		{"below parameter segment", fiber.MethodGet, "/api/v1/movies/42/history/3", models.RoleViewer, "", fiber.StatusForbidden},
+		{"editor on parameter segment", fiber.MethodGet, "/api/v1/Movies/42/History/", models.RoleEditor, "", fiber.StatusOK},
+		{"empty parameter segment", fiber.MethodGet, "/api/v1/movies//history", "", "", fiber.StatusUnauthorized},
+		{"parameter segment is one segment", fiber.MethodGet, "/api/v1/movies/42/reviews", "", "", fiber.StatusOK},
+		{"prefix needs a segment boundary", fiber.MethodGet, "/api/v1/moviesx", "", "", fiber.StatusUnauthorized},
+		{"anonymous public auth write", fiber.MethodPost, "/api/v1/auth/login", "", "", fiber.StatusOK},
+
+		{"anonymous read of admin group", fiber.MethodGet, "/api/v1/users", "", "", fiber.StatusUnauthorized},
+		{"editor read of admin group", fiber.MethodGet, "/api/v1/users", models.RoleEditor, "", fiber.StatusForbidden},
+		{"admin read of admin group", fiber.MethodGet, "/api/v1/users", models.RoleAdmin, "", fiber.StatusOK},
+
+		{"mixed case group", fiber.MethodGet, "/api/v1/Users", "", "", fiber.StatusUnauthorized},
+		{"mixed case base", fiber.MethodGet, "/api/V1/users", "", "", fiber.StatusUnauthorized},
+		{"upper case group", fiber.MethodGet, "/API/V1/USERS", models.RoleViewer, "", fiber.StatusForbidden},
+		{"trailing slash", fiber.MethodGet, "/api/v1/users/", "", "", fiber.StatusUnauthorized},
+		{"mixed case write", fiber.MethodPost, "/api/v1/Movies/Purge", models.RoleEditor, "", fiber.StatusForbidden},
+
+		{"anonymous read without policy", fiber.MethodGet, "/api/v1/unknown", "", "", fiber.StatusUnauthorized},
+		{"viewer read without policy", fiber.MethodGet, "/api/v1/unknown", models.RoleViewer, "", fiber.StatusForbidden},
+		{"admin read without policy", fiber.MethodGet, "/api/v1/unknown", models.RoleAdmin, "", fiber.StatusOK},
+
+		{"key with read scope reads", fiber.MethodGet, "/api/v1/movies", "", models.ScopeMoviesRead, fiber.StatusOK},
+		{"key without read scope", fiber.MethodGet, "/api/v1/movies", "", models.ScopeSyncRun, fiber.StatusForbidden},
+		{"key with read scope writes", fiber.MethodPost, "/api/v1/movies", "", models.ScopeMoviesRead, fiber.StatusForbidden},
+		{"key with write scope writes", fiber.MethodPost, "/api/v1/movies", "", models.ScopeMoviesWrite, fiber.StatusOK},
+		{"key on group without scope", fiber.MethodGet, "/api/v1/users", "", models.ScopeMoviesRead, fiber.StatusForbidden},
+		{"key on mixed case group without scope", fiber.MethodGet, "/api/v1/USERS", "", models.ScopeMoviesRead, fiber.StatusForbidden},
+		{"key on path without policy", fiber.MethodGet, "/api/v1/API-KEYS", "", models.ScopeMoviesRead, fiber.StatusForbidden},
+	}
+
+	app := newAuthorizeApp(fiber.Config{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.role != "" {
+				req.Header.Set("X-Test-Role", tt.role)
+			}
+			if tt.scopes != "" {
+				req.Header.Set("X-Test-Scopes", tt.scopes)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("%s %s: got status %d, want %d", tt.method, tt.path, resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizeCaseSensitiveRouting(t *testing.T) {
+	app := newAuthorizeApp(fiber.Config{CaseSensitive: true})
+
+	// With case sensitive routing "/Movies" is a different route than
+	// "/movies", so it falls back to the admin-only default
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/api/v1/Movies", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Errorf("got status %d, want %d", resp.StatusCode, fiber.StatusUnauthorized)
+	}
+}
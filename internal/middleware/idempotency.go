@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotentReplayed  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyAbandonedAfter = 5 * time.Minute
+)
+
+// Idempotency makes mutating requests sent with an Idempotency-Key header safe
+// to retry. The first response is stored in Postgres for ttl and replayed for
+// retries with the same key and request; reusing a key for a different
+// request is rejected with 422. Keys are scoped to the caller, so the same key
+// sent by another user or API key is a new request. Server errors are not
+// stored, so the request can be retried.
+func Idempotency(repo repository.IdempotencyRepository, ttl time.Duration, logger *logrus.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete:
+		default:
+			return c.Next()
+		}
+
+		key := c.Get(HeaderIdempotencyKey)
+		if key == "" {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+		}
+		key = strings.Clone(key)
+		actor := idempotencyActor(c)
+
+		record := &models.IdempotencyKey{
+			Actor:       actor,
+			Key:         key,
+			RequestHash: idempotencyRequestHash(c),
+			Method:      c.Method(),
+			Path:        strings.Clone(c.Path()),
+			Status:      models.IdempotencyStatusProcessing,
+			ExpiresAt:   time.Now().UTC().Add(ttl),
+		}
+
+		ctx := c.Context()
+		created, existing, err := repo.Reserve(ctx, record)
+		if err == nil && !created && existing.Status == models.IdempotencyStatusProcessing &&
+			time.Since(existing.CreatedAt) > idempotencyAbandonedAfter {
+			// The original request never finished, e.g. the server restarted
+			if err = repo.Release(ctx, actor, key); err == nil {
+				created, existing, err = repo.Reserve(ctx, record)
+			}
+		}
+		if err != nil {
+			logger.WithError(err).WithFields(logrus.Fields{"actor": actor, "idempotency_key": key}).Error("Failed to reserve idempotency key")
+			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to process Idempotency-Key")
+		}
+
+		if !created {
+			if existing.RequestHash != record.RequestHash {
+				return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+			}
+			if existing.Status != models.IdempotencyStatusCompleted {
+				return utils.ErrorResponse(c, fiber.StatusConflict, "A request with this Idempotency-Key is still being processed")
+			}
+
+			c.Set(HeaderIdempotentReplayed, "true")
+			if existing.ContentType != "" {
+				c.Set(fiber.HeaderContentType, existing.ContentType)
+			}
+			return c.Status(existing.StatusCode).Send(existing.ResponseBody)
+		}
+
+		if err := c.Next(); err != nil {
+			releaseIdempotencyKey(repo, actor, key, logger)
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			releaseIdempotencyKey(repo, actor, key, logger)
+			return nil
+		}
+
+		body := append([]byte(nil), c.Response().Body()...)
+		contentType := string(c.Response().Header.ContentType())
+		if err := repo.Complete(ctx, actor, key, status, contentType, body); err != nil {
+			logger.WithError(err).WithFields(logrus.Fields{"actor": actor, "idempotency_key": key}).Error("Failed to store idempotent response")
+		}
+		return nil
+	}
+}
+
+// StartIdempotencyCleanup periodically removes expired idempotency records
+// until ctx is cancelled
+func StartIdempotencyCleanup(ctx context.Context, repo repository.IdempotencyRepository, interval time.Duration, logger *logrus.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := repo.DeleteExpired(ctx)
+			if err != nil {
+				logger.WithError(err).Warn("Failed to delete expired idempotency keys")
+				continue
+			}
+			if deleted > 0 {
+				logger.WithField("deleted", deleted).Info("Expired idempotency keys deleted")
+			}
+		}
+	}
+}
+
+func releaseIdempotencyKey(repo repository.IdempotencyRepository, actor, key string, logger *logrus.Logger) {
+	if err := repo.Release(context.Background(), actor, key); err != nil {
+		logger.WithError(err).WithFields(logrus.Fields{"actor": actor, "idempotency_key": key}).Warn("Failed to release idempotency key")
+	}
+}
+
+// idempotencyActor scopes idempotency keys to the caller by user or API key
+// ID, which unlike names and emails never change or get reused
+func idempotencyActor(c *fiber.Ctx) string {
+	identity := IdentityFrom(c)
+	switch {
+	case identity == nil:
+		return ""
+	case identity.APIKey != nil:
+		return fmt.Sprintf("api-key:%d", identity.APIKey.ID)
+	default:
+		return fmt.Sprintf("user:%d", identity.UserID)
+	}
+}
+
+// idempotencyRequestHash fingerprints the method, URL and body of a request
+func idempotencyRequestHash(c *fiber.Ctx) string {
+	h := sha256.New()
+	h.Write([]byte(c.Method()))
+	h.Write([]byte{0})
+	h.Write([]byte(c.OriginalURL()))
+	h.Write([]byte{0})
+	h.Write(c.Body())
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"movie-backend/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// memoryIdempotencyRepository keeps idempotency records in a map keyed by
+// actor and key, like the unique index does
+type memoryIdempotencyRepository struct {
+	mu      sync.Mutex
+	records map[[2]string]*models.IdempotencyKey
+}
+
+func (r *memoryIdempotencyRepository) Reserve(_ context.Context, record *models.IdempotencyKey) (bool, *models.IdempotencyKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := [2]string{record.Actor, record.Key}
+	if existing, ok := r.records[id]; ok {
+		copied := *existing
+		return false, &copied, nil
+	}
+	stored := *record
+	stored.CreatedAt = time.Now()
+	r.records[id] = &stored
+	return true, nil, nil
+}
+
+func (r *memoryIdempotencyRepository) Complete(_ context.Context, actor, key string, statusCode int, contentType string, body []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record := r.records[[2]string{actor, key}]
+	record.Status = models.IdempotencyStatusCompleted
+	record.StatusCode, record.ContentType, record.ResponseBody = statusCode, contentType, body
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) Release(_ context.Context, actor, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.records, [2]string{actor, key})
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) DeleteExpired(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func TestIdempotencyKeysAreScopedToTheCaller(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	repo := &memoryIdempotencyRepository{records: map[[2]string]*models.IdempotencyKey{}}
+
+	created := 0
+	app := fiber.New()
+	// Stands in for Authenticate: X-Caller is "user:<id>" or "api-key:<id>"
+	app.Use(func(c *fiber.Ctx) error {
+		kind, id, ok := strings.Cut(c.Get("X-Caller"), ":")
+		if !ok {
+			return c.Next()
+		}
+		n, _ := strconv.Atoi(id)
+		identity := &models.Identity{UserID: uint(n), Role: models.RoleEditor}
+		if kind == "api-key" {
+			identity = &models.Identity{APIKey: &models.APIKey{ID: uint(n)}}
+		}
+		c.Locals(LocalsIdentityKey, identity)
+		return c.Next()
+	})
+	app.Use(Idempotency(repo, time.Hour, logger))
+	app.Post("/movies", func(c *fiber.Ctx) error {
+		created++
+		return c.Status(fiber.StatusCreated).SendString(strconv.Itoa(created))
+	})
+
+	tests := []struct {
+		name         string
+		caller       string
+		body         string
+		wantStatus   int
+		wantBody     string
+		wantReplayed bool
+	}{
+		{"first request", "user:1", "a", fiber.StatusCreated, "1", false},
+		{"retry is replayed", "user:1", "a", fiber.StatusCreated, "1", true},
+		{"same key from another user", "user:2", "a", fiber.StatusCreated, "2", false},
+		{"second user reusing the key", "user:2", "b", fiber.StatusUnprocessableEntity, "", false},
+		{"same key from an API key with the user's ID", "api-key:1", "b", fiber.StatusCreated, "3", false},
+		{"same key from an anonymous caller", "", "b", fiber.StatusCreated, "4", false},
+		{"first user reusing the key", "user:1", "b", fiber.StatusUnprocessableEntity, "", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(fiber.MethodPost, "/movies", strings.NewReader(tt.body))
+		req.Header.Set(HeaderIdempotencyKey, "create-movie")
+		if tt.caller != "" {
+			req.Header.Set("X-Caller", tt.caller)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("%s: request failed: %v", tt.name, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != tt.wantStatus {
+			t.Errorf("%s: got status %d, want %d", tt.name, resp.StatusCode, tt.wantStatus)
+		}
+		if tt.wantBody != "" && string(body) != tt.wantBody {
+			t.Errorf("%s: got body %q, want %q", tt.name, body, tt.wantBody)
+		}
+		if replayed := resp.Header.Get(HeaderIdempotentReplayed) == "true"; replayed != tt.wantReplayed {
+			t.Errorf("%s: got replayed %t, want %t", tt.name, replayed, tt.wantReplayed)
+		}
+	}
+}
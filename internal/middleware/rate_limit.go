@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"movie-backend/internal/config"
+	"movie-backend/internal/services"
+	"movie-backend/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// Rate limit response headers, from the IETF RateLimit header fields draft
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// RateLimit limits how many requests each client makes per route group:
+// reads, writes, and sync runs under syncPath. Clients are counted by API
+// key, then user, then IP, so it runs after Authenticate. Responses carry
+// RateLimit-* headers; requests over the limit get 429 with Retry-After. If
+// the counter store fails, requests are let through.
+func RateLimit(limiter services.RateLimiter, cfg config.RateLimitConfig, syncPath string, logger *logrus.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !cfg.Enabled {
+			return c.Next()
+		}
+
+		group, limit := "read", cfg.Read
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+		default:
+			group, limit = "write", cfg.Write
+			if underPath(c, syncPath) {
+				group, limit = "sync", cfg.Sync
+			}
+		}
+		if limit.Requests <= 0 {
+			return c.Next()
+		}
+
+		client := rateLimitClient(c)
+		result, err := limiter.Allow(c.Context(), group+":"+client, limit.Requests, limit.Window)
+		if err != nil {
+			logger.WithError(err).WithField("client", client).Warn("Rate limit check failed, allowing request")
+			return c.Next()
+		}
+
+		reset := secondsUntil(result.ResetAt)
+		c.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+		c.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+		c.Set(HeaderRateLimitReset, strconv.Itoa(reset))
+		c.Set(HeaderRateLimitPolicy, strconv.Itoa(limit.Requests)+";w="+strconv.Itoa(int(limit.Window.Seconds())))
+
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(reset))
+			return utils.ErrorResponse(c, fiber.StatusTooManyRequests, "Rate limit exceeded, retry in "+strconv.Itoa(reset)+" seconds")
+		}
+		return c.Next()
+	}
+}
+
+// RateLimitAuthFailures limits how often an IP can present an invalid access
+// token or API key. It runs before Authenticate, which rejects such requests
+// before RateLimit can count them against a caller; once an IP has used up
+// cfg.AuthFailure, requests with credentials get 429 without being verified.
+func RateLimitAuthFailures(limiter services.RateLimiter, cfg config.RateLimitConfig, logger *logrus.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		limit := cfg.AuthFailure
+		if !cfg.Enabled || limit.Requests <= 0 || (c.Get(fiber.HeaderAuthorization) == "" && c.Get(HeaderAPIKey) == "") {
+			return c.Next()
+		}
+
+		key := "auth-failure:ip:" + c.IP()
+		result, err := limiter.Peek(c.Context(), key, limit.Requests, limit.Window)
+		if err != nil {
+			logger.WithError(err).WithField("client", c.IP()).Warn("Rate limit check failed, allowing request")
+		} else if result.Remaining == 0 {
+			reset := secondsUntil(result.ResetAt)
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(reset))
+			return utils.ErrorResponse(c, fiber.StatusTooManyRequests, "Too many invalid credentials, retry in "+strconv.Itoa(reset)+" seconds")
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+		if c.Response().StatusCode() == fiber.StatusUnauthorized {
+			if _, err := limiter.Allow(c.Context(), key, limit.Requests, limit.Window); err != nil {
+				logger.WithError(err).WithField("client", c.IP()).Warn("Failed to count invalid credentials")
+			}
+		}
+		return nil
+	}
+}
+
+// secondsUntil returns the whole seconds until t, rounded up
+func secondsUntil(t time.Time) int {
+	seconds := int(time.Until(t).Seconds() + 0.999)
+	if seconds < 0 {
+		return 0
+	}
+	return seconds
+}
+
+// rateLimitClient identifies who a request is counted against
+func rateLimitClient(c *fiber.Ctx) string {
+	if identity := IdentityFrom(c); identity != nil {
+		if identity.APIKey != nil {
+			return "key:" + strconv.FormatUint(uint64(identity.APIKey.ID), 10)
+		}
+		return "user:" + strconv.FormatUint(uint64(identity.UserID), 10)
+	}
+	return "ip:" + c.IP()
+}
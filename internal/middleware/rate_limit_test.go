@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"movie-backend/internal/config"
+	"movie-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+func newRateLimitApp(cfg config.RateLimitConfig) *fiber.App {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	app := fiber.New()
+	v1 := app.Group("/api/v1")
+	v1.Use(RateLimit(services.NewMemoryRateLimiter(), cfg, "/api/v1/sync", logger))
+	v1.All("/*", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	app := newRateLimitApp(config.RateLimitConfig{
+		Enabled: true,
+		Read:    config.RateLimit{Requests: 2, Window: time.Hour},
+	})
+
+	tests := []struct {
+		wantStatus    int
+		wantRemaining string
+	}{
+		{fiber.StatusOK, "1"},
+		{fiber.StatusOK, "0"},
+		{fiber.StatusTooManyRequests, "0"},
+	}
+	for i, tt := range tests {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/api/v1/movies", nil))
+		if err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+		if resp.StatusCode != tt.wantStatus {
+			t.Errorf("request %d: got status %d, want %d", i, resp.StatusCode, tt.wantStatus)
+		}
+		if got := resp.Header.Get(HeaderRateLimitLimit); got != "2" {
+			t.Errorf("request %d: got %s %q, want %q", i, HeaderRateLimitLimit, got, "2")
+		}
+		if got := resp.Header.Get(HeaderRateLimitRemaining); got != tt.wantRemaining {
+			t.Errorf("request %d: got %s %q, want %q", i, HeaderRateLimitRemaining, got, tt.wantRemaining)
+		}
+		if got := resp.Header.Get(HeaderRateLimitPolicy); got != "2;w=3600" {
+			t.Errorf("request %d: got %s %q, want %q", i, HeaderRateLimitPolicy, got, "2;w=3600")
+		}
+
+		reset, err := strconv.Atoi(resp.Header.Get(HeaderRateLimitReset))
+		if err != nil || reset < 1 || reset > 3600 {
+			t.Errorf("request %d: got %s %q, want seconds until the window ends", i, HeaderRateLimitReset, resp.Header.Get(HeaderRateLimitReset))
+		}
+		retryAfter := resp.Header.Get(fiber.HeaderRetryAfter)
+		if tt.wantStatus == fiber.StatusTooManyRequests && retryAfter != strconv.Itoa(reset) {
+			t.Errorf("request %d: got %s %q, want %q", i, fiber.HeaderRetryAfter, retryAfter, strconv.Itoa(reset))
+		}
+		if tt.wantStatus == fiber.StatusOK && retryAfter != "" {
+			t.Errorf("request %d: got %s %q on an allowed request", i, fiber.HeaderRetryAfter, retryAfter)
+		}
+	}
+}
+
+func TestRateLimitGroups(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantPolicy string
+	}{
+		{"read", fiber.MethodGet, "/api/v1/sync/last-log", "100;w=60"},
+		{"write", fiber.MethodPost, "/api/v1/movies", "10;w=60"},
+		{"sync", fiber.MethodPost, "/api/v1/sync/movies", "1;w=3600"},
+		{"sync with mixed case", fiber.MethodPost, "/api/v1/Sync/movies", "1;w=3600"},
+		{"sync with upper case base", fiber.MethodPost, "/API/V1/SYNC/MOVIES", "1;w=3600"},
+		{"sync with trailing slash", fiber.MethodPost, "/api/v1/sync/", "1;w=3600"},
+		{"write next to sync", fiber.MethodPost, "/api/v1/syncs", "10;w=60"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newRateLimitApp(config.RateLimitConfig{
+				Enabled: true,
+				Read:    config.RateLimit{Requests: 100, Window: time.Minute},
+				Write:   config.RateLimit{Requests: 10, Window: time.Minute},
+				Sync:    config.RateLimit{Requests: 1, Window: time.Hour},
+			})
+
+			resp, err := app.Test(httptest.NewRequest(tt.method, tt.path, nil))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if got := resp.Header.Get(HeaderRateLimitPolicy); got != tt.wantPolicy {
+				t.Errorf("%s %s: got %s %q, want %q", tt.method, tt.path, HeaderRateLimitPolicy, got, tt.wantPolicy)
+			}
+		})
+	}
+}
+
+func TestRateLimitAuthFailures(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	app := fiber.New()
+	v1 := app.Group("/api/v1")
+	v1.Use(RateLimitAuthFailures(services.NewMemoryRateLimiter(), config.RateLimitConfig{
+		Enabled:     true,
+		AuthFailure: config.RateLimit{Requests: 2, Window: time.Hour},
+	}, logger))
+	// Stands in for Authenticate: only "Bearer good" and key "good" are valid
+	v1.Use(func(c *fiber.Ctx) error {
+		header, key := c.Get(fiber.HeaderAuthorization), c.Get(HeaderAPIKey)
+		if (header != "" && header != "Bearer good") || (key != "" && key != "good") {
+			return c.SendStatus(fiber.StatusUnauthorized)
+		}
+		return c.Next()
+	})
+	v1.All("/*", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{"valid token is not counted", fiber.HeaderAuthorization, "Bearer good", fiber.StatusOK},
+		{"valid token is not counted again", fiber.HeaderAuthorization, "Bearer good", fiber.StatusOK},
+		{"first invalid token", fiber.HeaderAuthorization, "Bearer guess-1", fiber.StatusUnauthorized},
+		{"invalid API key", HeaderAPIKey, "guess-2", fiber.StatusUnauthorized},
+		{"invalid token over the limit", fiber.HeaderAuthorization, "Bearer guess-3", fiber.StatusTooManyRequests},
+		{"invalid API key over the limit", HeaderAPIKey, "guess-4", fiber.StatusTooManyRequests},
+		{"valid token over the limit", fiber.HeaderAuthorization, "Bearer good", fiber.StatusTooManyRequests},
+		{"anonymous request", "", "", fiber.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/movies", nil)
+		if tt.header != "" {
+			req.Header.Set(tt.header, tt.value)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("%s: request failed: %v", tt.name, err)
+		}
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s: got status %d, want %d", tt.name, resp.StatusCode, tt.want)
+		}
+		if tt.want == fiber.StatusTooManyRequests && resp.Header.Get(fiber.HeaderRetryAfter) == "" {
+			t.Errorf("%s: missing %s", tt.name, fiber.HeaderRetryAfter)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// API key scopes. A key can only call the routes its scopes cover.
+const (
+	ScopeMoviesRead  = "movies:read"
+	ScopeMoviesWrite = "movies:write"
+	ScopeSyncRun     = "sync:run"
+	ScopeUploadWrite = "upload:write"
+)
+
+// APIKeyScopes are the scopes an API key can be given
+var APIKeyScopes = []string{ScopeMoviesRead, ScopeMoviesWrite, ScopeSyncRun, ScopeUploadWrite}
+
+// ValidScope reports whether scope is one of the API key scopes
+func ValidScope(scope string) bool {
+	for _, s := range APIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey lets batch jobs and partner integrations call the API without an
+// interactive login. Only a hash of the key is stored; the key itself is
+// returned once, when it is created.
+type APIKey struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Name        string     `gorm:"not null" json:"name" example:"nightly-sync"`
+	Prefix      string     `gorm:"not null;size:16" json:"prefix" example:"mk_3fZq9a"` // start of the key, to tell keys apart
+	KeyHash     string     `gorm:"not null;uniqueIndex;size:64" json:"-"`              // SHA-256 of the key, hex encoded
+	Scopes      []string   `gorm:"serializer:json;not null" json:"scopes" example:"movies:read,sync:run"`
+	ExpiresAt   *time.Time `gorm:"index" json:"expires_at"` // nil for keys that don't expire
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	CreatedByID uint       `gorm:"index" json:"created_by_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// HasScope reports whether the key was given scope
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CreatedAPIKey is the response of creating an API key, the only time the
+// key is shown
+type CreatedAPIKey struct {
+	Key    string  `json:"key" example:"mk_3fZq9aK1v8XcR0pLm2nB7wYt5uE4sD6gH9jQ1zA0oI3"`
+	APIKey *APIKey `json:"api_key"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Collection is a TMDB collection grouping the films of a franchise, e.g.
+// "The Matrix Collection"
+type Collection struct {
+	ID           uint      `gorm:"primaryKey" json:"id" example:"1"`
+	TMDBID       int       `gorm:"uniqueIndex;not null" json:"tmdb_id" example:"2344"`
+	Name         string    `gorm:"not null;index" json:"name" example:"The Matrix Collection"`
+	Overview     string    `gorm:"type:text" json:"overview"`
+	PosterPath   string    `json:"poster_path" example:"/bV9qTVHTVf0gkW0j7p7M0ILD4pG.jpg"`
+	BackdropPath string    `json:"backdrop_path" example:"/bRm2DEgUiYciDw3myHuYFInD7la.jpg"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (Collection) TableName() string {
+	return "collections"
+}
+
+// CollectionWithStats is a collection with aggregates over its movies in the
+// catalog. The years are nil while no movie has a release date.
+type CollectionWithStats struct {
+	Collection
+	MovieCount    int64   `json:"movie_count" example:"4"`
+	AverageRating float64 `json:"average_rating" example:"6.9"`
+	FirstYear     *int    `json:"first_year" example:"1999"`
+	LastYear      *int    `json:"last_year" example:"2021"`
+}
+
+// TMDBCollectionSummary is the belongs_to_collection part of TMDB movie details
+type TMDBCollectionSummary struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	PosterPath   string `json:"poster_path"`
+	BackdropPath string `json:"backdrop_path"`
+}
+
+// TMDBCollectionResponse is the TMDB /collection/{id} response
+type TMDBCollectionResponse struct {
+	ID           int                 `json:"id"`
+	Name         string              `json:"name"`
+	Overview     string              `json:"overview"`
+	PosterPath   string              `json:"poster_path"`
+	BackdropPath string              `json:"backdrop_path"`
+	Parts        []TMDBMovieResponse `json:"parts"`
+}
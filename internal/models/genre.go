@@ -4,7 +4,7 @@ import "time"
 
 type Genre struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
-	TMDBID    int       `gorm:"uniqueIndex;not null" json:"tmdb_id"`
+	TMDBID    *int      `gorm:"uniqueIndex" json:"tmdb_id"` // nil for genres created through the API
 	Name      string    `gorm:"not null;index" json:"name"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -24,3 +24,21 @@ type MovieGenre struct {
 func (MovieGenre) TableName() string {
 	return "movie_genres"
 }
+
+// GenreAlias maps the TMDB ID of a genre that was merged away to the genre it
+// was merged into, so syncs keep assigning that TMDB genre to the target
+type GenreAlias struct {
+	TMDBID    int       `gorm:"primaryKey;autoIncrement:false" json:"tmdb_id"`
+	GenreID   uint      `gorm:"not null;index" json:"genre_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (GenreAlias) TableName() string {
+	return "genre_aliases"
+}
+
+// GenreWithCount is a genre with the number of movies tagged with it
+type GenreWithCount struct {
+	Genre
+	MovieCount int64 `json:"movie_count"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Idempotency record states
+const (
+	IdempotencyStatusProcessing = "processing"
+	IdempotencyStatusCompleted  = "completed"
+)
+
+// IdempotencyKey stores the first response of a mutating request sent with an
+// Idempotency-Key header so that retries can be answered with it. Keys are
+// unique per actor, so callers can't collide with or probe each other's keys.
+type IdempotencyKey struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Actor        string    `gorm:"uniqueIndex:idx_idempotency_keys_actor_key,priority:1;size:64;not null;default:''" json:"actor"` // e.g. user:5 or api-key:3, empty for anonymous callers
+	Key          string    `gorm:"column:idempotency_key;uniqueIndex:idx_idempotency_keys_actor_key,priority:2;size:255;not null" json:"key"`
+	RequestHash  string    `gorm:"size:64;not null" json:"request_hash"`
+	Method       string    `gorm:"size:10;not null" json:"method"`
+	Path         string    `gorm:"not null" json:"path"`
+	Status       string    `gorm:"size:20;not null" json:"status"`
+	StatusCode   int       `json:"status_code"`
+	ContentType  string    `json:"content_type"`
+	ResponseBody []byte    `json:"-"`
+	ExpiresAt    time.Time `gorm:"index;not null" json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
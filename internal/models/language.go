@@ -8,8 +8,46 @@ type Language struct {
 	Name      string    `gorm:"not null" json:"name"`                     // Full name (e.g., 'English', 'Indonesian')
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	Translations []LanguageTranslation `gorm:"foreignKey:LanguageID;constraint:OnDelete:CASCADE" json:"translations,omitempty"`
 }
 
 func (Language) TableName() string {
 	return "languages"
 }
+
+// LanguageTranslation is the name of a language in a UI locale, e.g. the
+// English language is "Inggris" in the "id" locale
+type LanguageTranslation struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	LanguageID uint      `gorm:"not null;uniqueIndex:idx_language_translation_locale" json:"language_id"`
+	Locale     string    `gorm:"not null;size:20;uniqueIndex:idx_language_translation_locale" json:"locale"`
+	Name       string    `gorm:"not null" json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (LanguageTranslation) TableName() string {
+	return "language_translations"
+}
+
+// LanguageWithCount is a language with the number of its movies and its name
+// in the requested locale
+type LanguageWithCount struct {
+	Language
+	DisplayName string `json:"display_name"`
+	MovieCount  int64  `json:"movie_count"`
+}
+
+// LocalizedName returns the name of the language in the first locale that has
+// a translation, or the English name
+func (l *Language) LocalizedName(locales []string) string {
+	for _, locale := range locales {
+		for _, translation := range l.Translations {
+			if translation.Locale == locale {
+				return translation.Name
+			}
+		}
+	}
+	return l.Name
+}
@@ -2,26 +2,52 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Movie struct {
-	ID            uint      `gorm:"primaryKey" json:"id" example:"1"`
-	TMDBID        int       `gorm:"uniqueIndex;not null" json:"tmdb_id" example:"550"`
-	Title         string    `gorm:"not null;index" json:"title" example:"Fight Club"`
-	OriginalTitle string    `json:"original_title" example:"Fight Club"`
-	Overview      string    `gorm:"type:text" json:"overview" example:"A ticking-Loss insurance clerk..."`
-	ReleaseDate   string    `gorm:"index" json:"release_date" example:"1999-10-15"`
-	PosterPath    string    `json:"poster_path" example:"/pB8BM7pdSp6B6Ih7QZ4DrQ3PmJK.jpg"`
-	BackdropPath  string    `json:"backdrop_path" example:"/52AfXWuXCHn3UjD17rBruA9f5qb.jpg"`
-	VoteAverage   float64   `gorm:"index" json:"vote_average" example:"8.4"`
-	VoteCount     int       `json:"vote_count" example:"26280"`
-	Popularity    float64   `gorm:"index" json:"popularity" example:"61.416"`
-	Adult         bool      `json:"adult" example:"false"`
-	LanguageID    *uint     `gorm:"index" json:"language_id"`
-	Language      *Language `gorm:"foreignKey:LanguageID" json:"language,omitempty"`
-	Genres        []Genre   `gorm:"many2many:movie_genres;" json:"genres,omitempty"`
-	CreatedAt     time.Time `gorm:"index" json:"created_at"`
-	UpdatedAt     time.Time `gorm:"index" json:"updated_at"`
+	ID            uint           `gorm:"primaryKey" json:"id" example:"1"`
+	TMDBID        int            `gorm:"uniqueIndex;not null" json:"tmdb_id" example:"550"`
+	Title         string         `gorm:"not null;index" json:"title" example:"Fight Club"`
+	OriginalTitle string         `json:"original_title" example:"Fight Club"`
+	Overview      string         `gorm:"type:text" json:"overview" example:"A ticking-Loss insurance clerk..."`
+	Tagline       string         `json:"tagline" example:"Mischief. Mayhem. Soap."`
+	ReleaseDate   string         `gorm:"index" json:"release_date" example:"1999-10-15"`
+	PosterPath    string         `json:"poster_path" example:"/pB8BM7pdSp6B6Ih7QZ4DrQ3PmJK.jpg"`
+	BackdropPath  string         `json:"backdrop_path" example:"/52AfXWuXCHn3UjD17rBruA9f5qb.jpg"`
+	VoteAverage   float64        `gorm:"index" json:"vote_average" example:"8.4"`
+	VoteCount     int            `json:"vote_count" example:"26280"`
+	Popularity    float64        `gorm:"index" json:"popularity" example:"61.416"`
+	Adult         bool           `json:"adult" example:"false"`
+	Version       int64          `gorm:"not null;default:1" json:"version" example:"3"` // bumped on every write, checked by If-Match
+	LanguageID    *uint          `gorm:"index" json:"language_id"`
+	Language      *Language      `gorm:"foreignKey:LanguageID" json:"language,omitempty"`
+	Genres        []Genre        `gorm:"many2many:movie_genres;" json:"genres,omitempty"`
+	Keywords      []Keyword      `gorm:"many2many:movie_keywords;" json:"keywords,omitempty"`
+	Tags          []Tag          `gorm:"many2many:movie_tags;" json:"tags,omitempty"`
+	CollectionID  *uint          `gorm:"index" json:"collection_id"`
+	Collection    *Collection    `gorm:"foreignKey:CollectionID" json:"collection,omitempty"`
+	Locale        string         `gorm:"-" json:"locale,omitempty" example:"id-id"` // locale of title, overview and tagline when translated
+	InWatchlist   *bool          `gorm:"-" json:"in_watchlist,omitempty"`           // personal state, set for signed-in users
+	IsFavorite    *bool          `gorm:"-" json:"is_favorite,omitempty"`
+	IsWatched     *bool          `gorm:"-" json:"is_watched,omitempty"`
+	WatchedAt     *time.Time     `gorm:"-" json:"watched_at,omitempty"`
+	CreatedAt     time.Time      `gorm:"index" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"index" json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty" swaggertype:"string" example:"2024-01-01T00:00:00Z"`
+
+	// Local user rating of approved reviews. It is kept up to date when
+	// reviews change and never written by movie updates or syncs.
+	UserRatingAverage   float64    `gorm:"<-:false;not null;default:0;index" json:"user_rating_average" example:"7.8"`
+	UserRatingCount     int        `gorm:"<-:false;not null;default:0" json:"user_rating_count" example:"12"`
+	UserRatingHistogram []int      `gorm:"<-:false;type:jsonb;serializer:json" json:"user_rating_histogram,omitempty"` // number of ratings of 1 to 10
+	UserRatingUpdatedAt *time.Time `gorm:"<-:false" json:"user_rating_updated_at,omitempty"`
+
+	Translations []MovieTranslation   `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
+	Videos       []MovieVideo         `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
+	ReleaseDates []MovieReleaseDate   `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
+	Providers    []MovieWatchProvider `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
 }
 
 func (Movie) TableName() string {
@@ -51,21 +77,53 @@ type TMDBPopularMoviesResponse struct {
 	TotalResults int                 `json:"total_results"`
 }
 
+// TMDBMovieDetailsAppend is the append_to_response list of a details request
+const TMDBMovieDetailsAppend = "translations,videos,keywords,release_dates,watch/providers"
+
+// TMDBMovieDetailsResponse holds the fields of /movie/{id} that the popular
+// list does not include, with the TMDBMovieDetailsAppend responses. An
+// appended response is nil when TMDB left it out.
+type TMDBMovieDetailsResponse struct {
+	ID                  int                         `json:"id"`
+	BelongsToCollection *TMDBCollectionSummary      `json:"belongs_to_collection"`
+	Translations        *TMDBTranslationsResponse   `json:"translations"`
+	Videos              *TMDBVideosResponse         `json:"videos"`
+	Keywords            *TMDBKeywordsResponse       `json:"keywords"`
+	ReleaseDates        *TMDBReleaseDatesResponse   `json:"release_dates"`
+	WatchProviders      *TMDBWatchProvidersResponse `json:"watch/providers"`
+}
+
 type SyncLog struct {
-	ID            uint      `gorm:"primaryKey" json:"id" example:"1"`
-	SyncType      string    `gorm:"index" json:"sync_type" example:"manual"`
-	Status        string    `gorm:"index" json:"status" example:"success"`
-	MoviesAdded   int       `json:"movies_added" example:"20"`
-	MoviesUpdated int       `json:"movies_updated" example:"5"`
-	ErrorMessage  string    `gorm:"type:text" json:"error_message,omitempty"`
-	SyncedAt      time.Time `gorm:"index" json:"synced_at"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID            uint                `gorm:"primaryKey" json:"id" example:"1"`
+	SyncType      string              `gorm:"index" json:"sync_type" example:"manual"`
+	Status        string              `gorm:"index" json:"status" example:"success"`
+	MoviesAdded   int                 `json:"movies_added" example:"20"`
+	MoviesUpdated int                 `json:"movies_updated" example:"5"`
+	Conflicts     int                 `json:"conflicts" example:"0"` // movies skipped because they were edited during the sync
+	FieldsSkipped int                 `json:"fields_skipped" example:"2"`
+	SkippedFields []SyncSkippedFields `gorm:"type:jsonb;serializer:json" json:"skipped_fields,omitempty"` // locked fields left unchanged
+	ErrorMessage  string              `gorm:"type:text" json:"error_message,omitempty"`
+	SyncedAt      time.Time           `gorm:"index" json:"synced_at"`
+	CreatedAt     time.Time           `json:"created_at"`
 }
 
 func (SyncLog) TableName() string {
 	return "sync_logs"
 }
 
+// CatalogState tracks a version number that is bumped whenever movies are
+// written or a sync completes. It backs the ETags of list, chart and
+// dashboard responses.
+type CatalogState struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Version   int64     `gorm:"not null;default:0" json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (CatalogState) TableName() string {
+	return "catalog_state"
+}
+
 type DashboardStats struct {
 	TotalMovies    int64      `json:"total_movies" example:"100"`
 	AverageRating  float64    `json:"average_rating" example:"7.5"`
@@ -74,6 +132,14 @@ type DashboardStats struct {
 	TopRatedMovies []Movie    `json:"top_rated_movies"`
 	MostPopular    []Movie    `json:"most_popular"`
 	RecentlyAdded  []Movie    `json:"recently_added"`
+
+	// Local community scores, next to the TMDB average_rating and total_votes
+	AverageUserRating float64 `json:"average_user_rating" example:"7.2"` // mean of all approved user ratings
+	TotalUserRatings  int64   `json:"total_user_ratings" example:"340"`
+	RatedMovies       int64   `json:"rated_movies" example:"85"` // movies with at least one approved user rating
+	TopRatedByUsers   []Movie `json:"top_rated_by_users"`
+
+	ProviderCoverage []ProviderCoverage `json:"provider_coverage"` // most available providers first
 }
 
 type PieChartData struct {
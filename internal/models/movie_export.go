@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// MovieExportRow is a flattened movie row used by the catalog export
+type MovieExportRow struct {
+	ID            uint
+	TMDBID        int
+	Title         string
+	OriginalTitle string
+	Overview      string
+	ReleaseDate   string
+	PosterPath    string
+	BackdropPath  string
+	VoteAverage   float64
+	VoteCount     int
+	Popularity    float64
+	Adult         bool
+	LanguageCode  string
+	LanguageName  string
+	Genres        string // Genre names separated by "|"
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// MovieExportColumns lists the exportable columns in their default order
+var MovieExportColumns = []string{
+	"id", "tmdb_id", "title", "original_title", "overview", "release_date",
+	"poster_path", "backdrop_path", "vote_average", "vote_count", "popularity",
+	"adult", "language_code", "language", "genres", "created_at", "updated_at",
+}
+
+// Value returns the value of the given export column
+func (r *MovieExportRow) Value(column string) interface{} {
+	switch column {
+	case "id":
+		return r.ID
+	case "tmdb_id":
+		return r.TMDBID
+	case "title":
+		return r.Title
+	case "original_title":
+		return r.OriginalTitle
+	case "overview":
+		return r.Overview
+	case "release_date":
+		return r.ReleaseDate
+	case "poster_path":
+		return r.PosterPath
+	case "backdrop_path":
+		return r.BackdropPath
+	case "vote_average":
+		return r.VoteAverage
+	case "vote_count":
+		return r.VoteCount
+	case "popularity":
+		return r.Popularity
+	case "adult":
+		return r.Adult
+	case "language_code":
+		return r.LanguageCode
+	case "language":
+		return r.LanguageName
+	case "genres":
+		return r.Genres
+	case "created_at":
+		return r.CreatedAt.UTC().Format(time.RFC3339)
+	case "updated_at":
+		return r.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	return nil
+}
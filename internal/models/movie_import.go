@@ -0,0 +1,61 @@
+package models
+
+// Import row statuses
+const (
+	ImportStatusCreated    = "created"
+	ImportStatusUpdated    = "updated"
+	ImportStatusFailed     = "failed"
+	ImportStatusRolledBack = "rolled_back"
+)
+
+// MovieImportRecord is a single parsed row from an import file. Fields holds
+// the raw values keyed by normalized column name (see MovieImportColumns).
+type MovieImportRecord struct {
+	Row    int
+	Fields map[string]string
+}
+
+// MovieImportColumns lists the columns understood by the importer. They match
+// the JSON field names of the movie request, plus "genres".
+var MovieImportColumns = []string{
+	"tmdb_id", "title", "original_title", "overview", "release_date",
+	"poster_path", "backdrop_path", "vote_average", "vote_count",
+	"popularity", "adult", "original_language", "genres",
+}
+
+// MovieImportColumnAliases maps alternative header names to import columns
+var MovieImportColumnAliases = map[string]string{
+	"id_tmdb":  "tmdb_id",
+	"tmdb":     "tmdb_id",
+	"name":     "title",
+	"language": "original_language",
+	"lang":     "original_language",
+	"genre":    "genres",
+	"rating":   "vote_average",
+	"poster":   "poster_path",
+	"backdrop": "backdrop_path",
+}
+
+type MovieImportOptions struct {
+	Upsert bool `json:"upsert"`
+	Atomic bool `json:"atomic"`
+}
+
+type MovieImportRowResult struct {
+	Row     int      `json:"row" example:"2"`
+	Status  string   `json:"status" example:"created"`
+	MovieID uint     `json:"movie_id,omitempty" example:"42"`
+	TMDBID  int      `json:"tmdb_id,omitempty" example:"550"`
+	Title   string   `json:"title,omitempty" example:"Fight Club"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+type MovieImportReport struct {
+	Options    MovieImportOptions     `json:"options"`
+	TotalRows  int                    `json:"total_rows" example:"10"`
+	Created    int                    `json:"created" example:"7"`
+	Updated    int                    `json:"updated" example:"2"`
+	Failed     int                    `json:"failed" example:"1"`
+	RolledBack bool                   `json:"rolled_back" example:"false"`
+	Rows       []MovieImportRowResult `json:"rows"`
+}
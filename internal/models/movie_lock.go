@@ -0,0 +1,110 @@
+package models
+
+import "time"
+
+// MovieFieldLock keeps TMDB sync from overwriting a field of a movie. Fields
+// use the names of MovieAuditState, e.g. "title" or "genre_ids".
+type MovieFieldLock struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	MovieID   uint      `gorm:"not null;uniqueIndex:idx_movie_field_lock" json:"movie_id"`
+	Field     string    `gorm:"not null;size:50;uniqueIndex:idx_movie_field_lock" json:"field" example:"title"`
+	LockedBy  string    `gorm:"not null" json:"locked_by" example:"anonymous"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (MovieFieldLock) TableName() string {
+	return "movie_field_locks"
+}
+
+// LockableMovieFields lists the fields that can be locked
+var LockableMovieFields = []string{
+	"title", "original_title", "overview", "tagline", "release_date",
+	"poster_path", "backdrop_path", "vote_average", "vote_count", "popularity",
+	"adult", "language_id", "genre_ids",
+}
+
+// IsLockableMovieField reports whether field can be locked
+func IsLockableMovieField(field string) bool {
+	for _, f := range LockableMovieFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// SyncSkippedFields lists the locked fields of a movie that a sync left
+// unchanged although TMDB had a different value
+type SyncSkippedFields struct {
+	MovieID uint     `json:"movie_id"`
+	TMDBID  int      `json:"tmdb_id"`
+	Fields  []string `json:"fields"`
+}
+
+// KeepLockedFields copies the locked fields of existing onto m, which holds
+// the incoming TMDB data, and returns the locked fields whose TMDB value
+// differed. A locked genre_ids field clears m.Genres so the stored genres are
+// left alone.
+func (m *Movie) KeepLockedFields(existing *Movie, locked map[string]bool) []string {
+	if len(locked) == 0 {
+		return nil
+	}
+
+	incomingState, currentState := m.AuditState(), existing.AuditState()
+	incoming, _ := auditStateFields(&incomingState)
+	current, _ := auditStateFields(&currentState)
+
+	var skipped []string
+	for _, field := range LockableMovieFields {
+		if !locked[field] {
+			continue
+		}
+		if string(incoming[field]) != string(current[field]) {
+			skipped = append(skipped, field)
+		}
+		m.copyField(existing, field)
+	}
+	return skipped
+}
+
+func (m *Movie) copyField(from *Movie, field string) {
+	switch field {
+	case "title":
+		m.Title = from.Title
+	case "original_title":
+		m.OriginalTitle = from.OriginalTitle
+	case "overview":
+		m.Overview = from.Overview
+	case "tagline":
+		m.Tagline = from.Tagline
+	case "release_date":
+		m.ReleaseDate = from.ReleaseDate
+	case "poster_path":
+		m.PosterPath = from.PosterPath
+	case "backdrop_path":
+		m.BackdropPath = from.BackdropPath
+	case "vote_average":
+		m.VoteAverage = from.VoteAverage
+	case "vote_count":
+		m.VoteCount = from.VoteCount
+	case "popularity":
+		m.Popularity = from.Popularity
+	case "adult":
+		m.Adult = from.Adult
+	case "language_id":
+		m.LanguageID = from.LanguageID
+	case "genre_ids":
+		m.Genres = nil
+	}
+}
+
+// ChangedFields returns the names of the fields in a revision diff
+func ChangedFields(changes map[string]FieldChange) []string {
+	fields := make([]string, 0, len(changes))
+	for _, field := range LockableMovieFields {
+		if _, ok := changes[field]; ok {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
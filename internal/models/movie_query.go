@@ -0,0 +1,76 @@
+package models
+
+// MovieFilter holds the filters and sorting shared by the movie list and export
+type MovieFilter struct {
+	Page             int
+	Limit            int
+	Search           string
+	SortBy           string
+	Order            string
+	StartDate        string
+	EndDate          string
+	Region           string // ISO 3166-1 country; dates, certifications and providers of this country
+	MaxCertification string // most restrictive certification allowed in Region
+	GenreID          uint
+	CollectionID     uint
+	TagID            uint
+	KeywordID        uint
+	ProviderID       uint
+	HasTrailer       *bool  // nil: no filter
+	Trashed          bool   // list soft-deleted movies instead of live ones
+	UserID           uint   // with UserList, only movies on this user's list
+	UserList         string // watchlist, favorites or watched
+}
+
+// MovieProjection selects the columns and relations loaded for a movie query.
+// Empty Fields selects every column; nil Expand loads every relation except
+// the opt-in ones. Locales lists the preferred locales for title, overview and
+// tagline.
+type MovieProjection struct {
+	Fields  []string
+	Expand  []string
+	Locales []string
+}
+
+// MovieFieldColumns lists the movie fields that can be requested with
+// ?fields=, which are also the database column names
+var MovieFieldColumns = []string{
+	"id", "tmdb_id", "title", "original_title", "overview", "tagline", "release_date",
+	"poster_path", "backdrop_path", "vote_average", "vote_count", "popularity",
+	"adult", "version", "language_id", "collection_id", "created_at", "updated_at", "deleted_at",
+	"user_rating_average", "user_rating_count", "user_rating_histogram", "user_rating_updated_at",
+}
+
+// MovieETagColumns are selected with every ?fields= projection, since the
+// movie ETag and Last-Modified are built from them
+var MovieETagColumns = []string{"id", "version", "updated_at", "user_rating_updated_at"}
+
+// MovieExpandRelations lists the relations that can be requested with ?expand=,
+// mapped to their preload names
+var MovieExpandRelations = map[string]string{
+	"language":   "Language",
+	"genres":     "Genres",
+	"collection": "Collection",
+	"keywords":   "Keywords",
+	"tags":       "Tags",
+}
+
+// MovieOptInRelations are only loaded when ?expand= lists them, since they are
+// many-to-many and rarely needed in lists
+var MovieOptInRelations = map[string]bool{
+	"keywords": true,
+	"tags":     true,
+}
+
+// Expands reports whether the relation should be loaded
+func (p MovieProjection) Expands(relation string) bool {
+	if p.Expand == nil {
+		return !MovieOptInRelations[relation]
+	}
+	for _, r := range p.Expand {
+		if r == relation {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package models
+
+import "testing"
+
+func TestMovieProjectionExpands(t *testing.T) {
+	tests := []struct {
+		name     string
+		expand   []string
+		relation string
+		want     bool
+	}{
+		{"default loads language", nil, "language", true},
+		{"default loads genres", nil, "genres", true},
+		{"default loads collection", nil, "collection", true},
+		{"default skips keywords", nil, "keywords", false},
+		{"default skips tags", nil, "tags", false},
+		{"empty expand loads nothing", []string{}, "genres", false},
+		{"listed keywords are loaded", []string{"keywords"}, "keywords", true},
+		{"listed tags are loaded", []string{"genres", "tags"}, "tags", true},
+		{"unlisted relation is skipped", []string{"tags"}, "genres", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projection := MovieProjection{Expand: tt.expand}
+			if got := projection.Expands(tt.relation); got != tt.want {
+				t.Errorf("Expands(%q) with expand %v = %t, want %t", tt.relation, tt.expand, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// Review moderation states. A rating without text needs no moderation and
+// is approved right away; reviews with a title or body wait for an editor.
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// Bounds of a user rating
+const (
+	MinUserRating = 1
+	MaxUserRating = 10
+)
+
+// ValidReviewStatus reports whether status is one of the moderation states
+func ValidReviewStatus(status string) bool {
+	switch status {
+	case ReviewStatusPending, ReviewStatusApproved, ReviewStatusRejected:
+		return true
+	}
+	return false
+}
+
+// MovieReview is a user's rating of a movie, optionally with a written
+// review. Each user has at most one per movie. Only approved reviews count
+// towards the movie's user rating and are shown publicly.
+type MovieReview struct {
+	ID             uint       `gorm:"primaryKey" json:"id" example:"1"`
+	MovieID        uint       `gorm:"not null;uniqueIndex:idx_movie_reviews_movie_user;index:idx_movie_reviews_movie_status,priority:1" json:"movie_id" example:"1"`
+	UserID         uint       `gorm:"not null;uniqueIndex:idx_movie_reviews_movie_user;index" json:"user_id" example:"3"`
+	Author         string     `gorm:"->;-:migration" json:"author,omitempty" example:"Jane Viewer"` // name of the user, read from users
+	Rating         int        `gorm:"not null" json:"rating" example:"8"`
+	Title          string     `gorm:"size:200" json:"title,omitempty" example:"Still holds up"`
+	Body           string     `gorm:"type:text" json:"body,omitempty" example:"The twist works even on a rewatch."`
+	Status         string     `gorm:"not null;size:20;index;index:idx_movie_reviews_movie_status,priority:2" json:"status" example:"approved"`
+	ModeratedByID  *uint      `json:"moderated_by_id,omitempty"`
+	ModeratedAt    *time.Time `json:"moderated_at,omitempty"`
+	ModerationNote string     `gorm:"type:text" json:"moderation_note,omitempty" example:"Contains spoilers"`
+	CreatedAt      time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (MovieReview) TableName() string {
+	return "movie_reviews"
+}
+
+// HasText reports whether the review has a title or body to moderate
+func (r *MovieReview) HasText() bool {
+	return r.Title != "" || r.Body != ""
+}
+
+// ReviewFilter selects reviews for the moderation queue and movie pages
+type ReviewFilter struct {
+	MovieID uint
+	UserID  uint
+	Status  string
+	Page    int
+	Limit   int
+}
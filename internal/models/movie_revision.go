@@ -0,0 +1,152 @@
+package models
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// Revision actions
+const (
+	RevisionActionCreate  = "create"
+	RevisionActionUpdate  = "update"
+	RevisionActionDelete  = "delete"
+	RevisionActionRestore = "restore"
+	RevisionActionRevert  = "revert"
+)
+
+// MovieRevision records one change to a movie. Revisions are numbered per
+// movie starting at 1. Changes maps each changed field to its old and new
+// value; Snapshot holds the audited fields after the change and is what a
+// revert restores.
+type MovieRevision struct {
+	ID           uint            `gorm:"primaryKey" json:"id"`
+	MovieID      uint            `gorm:"not null;uniqueIndex:idx_movie_revision_number" json:"movie_id"`
+	Revision     int             `gorm:"not null;uniqueIndex:idx_movie_revision_number" json:"revision" example:"3"`
+	Action       string          `gorm:"not null;size:20" json:"action" example:"update"`
+	Source       string          `gorm:"not null;size:20;index" json:"source" example:"api"`
+	Actor        string          `gorm:"not null" json:"actor" example:"anonymous"`
+	ActorRole    string          `gorm:"size:20" json:"actor_role,omitempty" example:"editor"`
+	RequestID    string          `json:"request_id,omitempty"`
+	RevertedFrom *int            `json:"reverted_from,omitempty"`
+	Changes      json.RawMessage `gorm:"type:jsonb;not null" json:"changes" swaggertype:"object"`
+	Snapshot     json.RawMessage `gorm:"type:jsonb;not null" json:"snapshot" swaggertype:"object"`
+	CreatedAt    time.Time       `gorm:"index" json:"created_at"`
+}
+
+func (MovieRevision) TableName() string {
+	return "movie_revisions"
+}
+
+// FieldChange is the old and new value of a field in a revision diff
+type FieldChange struct {
+	Old json.RawMessage `json:"old"`
+	New json.RawMessage `json:"new"`
+}
+
+// MovieAuditState holds the movie fields tracked by revisions
+type MovieAuditState struct {
+	TMDBID        int     `json:"tmdb_id"`
+	Title         string  `json:"title"`
+	OriginalTitle string  `json:"original_title"`
+	Overview      string  `json:"overview"`
+	Tagline       string  `json:"tagline"`
+	ReleaseDate   string  `json:"release_date"`
+	PosterPath    string  `json:"poster_path"`
+	BackdropPath  string  `json:"backdrop_path"`
+	VoteAverage   float64 `json:"vote_average"`
+	VoteCount     int     `json:"vote_count"`
+	Popularity    float64 `json:"popularity"`
+	Adult         bool    `json:"adult"`
+	LanguageID    *uint   `json:"language_id"`
+	GenreIDs      []uint  `json:"genre_ids"`
+}
+
+// AuditState returns the tracked fields of the movie. Genres must be loaded.
+func (m *Movie) AuditState() MovieAuditState {
+	genreIDs := make([]uint, 0, len(m.Genres))
+	for _, genre := range m.Genres {
+		genreIDs = append(genreIDs, genre.ID)
+	}
+	sort.Slice(genreIDs, func(i, j int) bool { return genreIDs[i] < genreIDs[j] })
+
+	return MovieAuditState{
+		TMDBID:        m.TMDBID,
+		Title:         m.Title,
+		OriginalTitle: m.OriginalTitle,
+		Overview:      m.Overview,
+		Tagline:       m.Tagline,
+		ReleaseDate:   m.ReleaseDate,
+		PosterPath:    m.PosterPath,
+		BackdropPath:  m.BackdropPath,
+		VoteAverage:   m.VoteAverage,
+		VoteCount:     m.VoteCount,
+		Popularity:    m.Popularity,
+		Adult:         m.Adult,
+		LanguageID:    m.LanguageID,
+		GenreIDs:      genreIDs,
+	}
+}
+
+// ApplyAuditState copies the tracked scalar fields onto the movie. Genres are
+// left to the caller since they have to be resolved.
+func (m *Movie) ApplyAuditState(state MovieAuditState) {
+	m.TMDBID = state.TMDBID
+	m.Title = state.Title
+	m.OriginalTitle = state.OriginalTitle
+	m.Overview = state.Overview
+	m.Tagline = state.Tagline
+	m.ReleaseDate = state.ReleaseDate
+	m.PosterPath = state.PosterPath
+	m.BackdropPath = state.BackdropPath
+	m.VoteAverage = state.VoteAverage
+	m.VoteCount = state.VoteCount
+	m.Popularity = state.Popularity
+	m.Adult = state.Adult
+	m.LanguageID = state.LanguageID
+}
+
+// DiffAuditStates returns the fields that differ between two states. A nil
+// state stands for a movie that does not exist, so every field is reported.
+func DiffAuditStates(before, after *MovieAuditState) (map[string]FieldChange, error) {
+	oldFields, err := auditStateFields(before)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := auditStateFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]FieldChange)
+	for field, newValue := range newFields {
+		oldValue, ok := oldFields[field]
+		if !ok {
+			oldValue = json.RawMessage("null")
+		}
+		if string(oldValue) != string(newValue) {
+			changes[field] = FieldChange{Old: oldValue, New: newValue}
+		}
+	}
+	for field, oldValue := range oldFields {
+		if _, ok := newFields[field]; !ok {
+			changes[field] = FieldChange{Old: oldValue, New: json.RawMessage("null")}
+		}
+	}
+	return changes, nil
+}
+
+func auditStateFields(state *MovieAuditState) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if state == nil {
+		return fields, nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// MovieTranslation holds the title, overview and tagline of a movie in a
+// locale synced from TMDB. Locales are stored lowercase, e.g. "id-id".
+type MovieTranslation struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	MovieID   uint      `gorm:"not null;uniqueIndex:idx_movie_translation_locale" json:"movie_id"`
+	Locale    string    `gorm:"not null;size:20;uniqueIndex:idx_movie_translation_locale" json:"locale"`
+	Title     string    `gorm:"index" json:"title"`
+	Overview  string    `gorm:"type:text" json:"overview"`
+	Tagline   string    `json:"tagline"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (MovieTranslation) TableName() string {
+	return "movie_translations"
+}
+
+// TMDBTranslationsResponse is the response of /movie/{id}/translations
+type TMDBTranslationsResponse struct {
+	ID           int `json:"id"`
+	Translations []struct {
+		ISO6391  string `json:"iso_639_1"`
+		ISO31661 string `json:"iso_3166_1"`
+		Data     struct {
+			Title    string `json:"title"`
+			Overview string `json:"overview"`
+			Tagline  string `json:"tagline"`
+		} `json:"data"`
+	} `json:"translations"`
+}
+
+// LocaleBase returns the language part of a locale, e.g. "id" for "id-id"
+func LocaleBase(locale string) string {
+	base, _, _ := strings.Cut(locale, "-")
+	return base
+}
+
+// Localize replaces the title, overview and tagline with the translation for
+// the first preferred locale that has one. A locale without a region matches
+// any region of that language. English is the base language of the catalog,
+// so preferring English, or having no matching translation, keeps the
+// original text. Empty translated fields fall back to English as well.
+func (m *Movie) Localize(locales []string) {
+	for _, locale := range locales {
+		if LocaleBase(locale) == "en" {
+			return
+		}
+		if translation := m.findTranslation(locale); translation != nil {
+			if translation.Title != "" {
+				m.Title = translation.Title
+			}
+			if translation.Overview != "" {
+				m.Overview = translation.Overview
+			}
+			if translation.Tagline != "" {
+				m.Tagline = translation.Tagline
+			}
+			m.Locale = translation.Locale
+			return
+		}
+	}
+}
+
+func (m *Movie) findTranslation(locale string) *MovieTranslation {
+	for i := range m.Translations {
+		if m.Translations[i].Locale == locale {
+			return &m.Translations[i]
+		}
+	}
+	if strings.Contains(locale, "-") {
+		return nil
+	}
+	for i := range m.Translations {
+		if LocaleBase(m.Translations[i].Locale) == locale {
+			return &m.Translations[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// VideoTypeTrailer is the TMDB video type of trailers
+const VideoTypeTrailer = "Trailer"
+
+// MovieVideo is a trailer, teaser, clip or other video of a movie synced from
+// TMDB. Key identifies the video on Site, e.g. the YouTube video ID.
+type MovieVideo struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	MovieID     uint       `gorm:"not null;index" json:"movie_id"`
+	TMDBID      string     `gorm:"not null;size:40" json:"tmdb_id" example:"5e382d1b4ca676001453826d"`
+	Name        string     `json:"name" example:"Official Trailer"`
+	Site        string     `gorm:"not null;size:40" json:"site" example:"YouTube"`
+	Key         string     `gorm:"not null" json:"key" example:"qtRKdVHc-cE"`
+	Type        string     `gorm:"not null;size:40;index" json:"type" example:"Trailer"`
+	Official    bool       `json:"official" example:"true"`
+	Size        int        `json:"size" example:"1080"`
+	Language    string     `gorm:"size:10" json:"language" example:"en"`
+	Region      string     `gorm:"size:10" json:"region" example:"US"`
+	PublishedAt *time.Time `json:"published_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (MovieVideo) TableName() string {
+	return "movie_videos"
+}
+
+// TMDBVideosResponse is the response of /movie/{id}/videos
+type TMDBVideosResponse struct {
+	ID      int `json:"id"`
+	Results []struct {
+		ID          string `json:"id"`
+		ISO6391     string `json:"iso_639_1"`
+		ISO31661    string `json:"iso_3166_1"`
+		Name        string `json:"name"`
+		Key         string `json:"key"`
+		Site        string `json:"site"`
+		Size        int    `json:"size"`
+		Type        string `json:"type"`
+		Official    bool   `json:"official"`
+		PublishedAt string `json:"published_at"`
+	} `json:"results"`
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// TMDB release types
+const (
+	ReleaseTypePremiere          = 1
+	ReleaseTypeTheatricalLimited = 2
+	ReleaseTypeTheatrical        = 3
+	ReleaseTypeDigital           = 4
+	ReleaseTypePhysical          = 5
+	ReleaseTypeTV                = 6
+)
+
+// MovieReleaseDate is a release of a movie in one country, synced from TMDB
+// /movie/{id}/release_dates. Certification is the age rating of the release
+// in that country and may be empty.
+type MovieReleaseDate struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	MovieID       uint      `gorm:"not null;index" json:"movie_id"`
+	Country       string    `gorm:"not null;size:2;index" json:"country" example:"US"` // ISO 3166-1
+	Type          int       `gorm:"not null" json:"type" example:"3"`
+	Certification string    `gorm:"size:40" json:"certification" example:"PG-13"`
+	ReleaseDate   string    `gorm:"not null;size:10" json:"release_date" example:"2019-04-26"`
+	Language      string    `gorm:"size:10" json:"language,omitempty" example:"en"`
+	Note          string    `json:"note,omitempty" example:"Premiere"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (MovieReleaseDate) TableName() string {
+	return "release_dates"
+}
+
+// certificationLadders lists the certifications of a country from least to
+// most restrictive, as TMDB spells them
+var certificationLadders = map[string][]string{
+	"AU": {"G", "PG", "M", "MA15+", "R18+", "X18+"},
+	"BR": {"L", "10", "12", "14", "16", "18"},
+	"CA": {"G", "PG", "14A", "18A", "R", "A"},
+	"DE": {"0", "6", "12", "16", "18"},
+	"FR": {"U", "10", "12", "16", "18"},
+	"GB": {"U", "PG", "12A", "12", "15", "18", "R18"},
+	"ID": {"SU", "13+", "17+", "21+"},
+	"IN": {"U", "UA", "A"},
+	"JP": {"G", "PG12", "R15+", "R18+"},
+	"KR": {"All", "12", "15", "18", "Restricted Screening"},
+	"NL": {"AL", "6", "9", "12", "14", "16", "18"},
+	"US": {"G", "PG", "PG-13", "R", "NC-17"},
+}
+
+// CertificationsUpTo returns the certifications of country that are no more
+// restrictive than max. ok is false when the country or certification is not
+// known.
+func CertificationsUpTo(country, max string) (certifications []string, ok bool) {
+	for _, certification := range certificationLadders[strings.ToUpper(country)] {
+		certifications = append(certifications, certification)
+		if strings.EqualFold(certification, max) {
+			return certifications, true
+		}
+	}
+	return nil, false
+}
+
+// TMDBReleaseDatesResponse is the response of /movie/{id}/release_dates
+type TMDBReleaseDatesResponse struct {
+	ID      int `json:"id"`
+	Results []struct {
+		ISO31661     string `json:"iso_3166_1"`
+		ReleaseDates []struct {
+			Certification string `json:"certification"`
+			ISO6391       string `json:"iso_639_1"`
+			Note          string `json:"note"`
+			ReleaseDate   string `json:"release_date"`
+			Type          int    `json:"type"`
+		} `json:"release_dates"`
+	} `json:"results"`
+}
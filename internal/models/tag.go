@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Tag is an editor-defined label for curating movies
+type Tag struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"not null;uniqueIndex:idx_tags_name_lower,expression:lower(name)" json:"name" example:"Staff Picks"` // unique regardless of case
+	Description string    `gorm:"type:text" json:"description,omitempty" example:"Favourites of the editorial team"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// TagWithCount is a tag with the number of movies carrying it
+type TagWithCount struct {
+	Tag
+	MovieCount int64 `json:"movie_count"`
+}
+
+// Keyword is a TMDB keyword, synced from /movie/{id}/keywords
+type Keyword struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	TMDBID    int       `gorm:"uniqueIndex;not null" json:"tmdb_id" example:"4565"`
+	Name      string    `gorm:"not null;index" json:"name" example:"dystopia"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Keyword) TableName() string {
+	return "keywords"
+}
+
+// Tag cloud entry types
+const (
+	TagCloudTypeTag     = "tag"
+	TagCloudTypeKeyword = "keyword"
+)
+
+// TagCloudEntry is a tag or keyword with the number of movies it is on
+type TagCloudEntry struct {
+	ID         uint   `json:"id"`
+	Name       string `json:"name" example:"dystopia"`
+	Type       string `json:"type" example:"keyword"`
+	MovieCount int64  `json:"movie_count" example:"12"`
+}
+
+// TMDBKeywordsResponse is the response of /movie/{id}/keywords
+type TMDBKeywordsResponse struct {
+	ID       int `json:"id"`
+	Keywords []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"keywords"`
+}
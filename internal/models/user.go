@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+// User roles, from least to most privileged. Each role can do everything the
+// roles before it can.
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+var roleRanks = map[string]int{RoleViewer: 1, RoleEditor: 2, RoleAdmin: 3}
+
+// ValidRole reports whether role is one of the user roles
+func ValidRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// RoleAllows reports whether role grants at least the privileges of required
+func RoleAllows(role, required string) bool {
+	return roleRanks[role] >= roleRanks[required] && roleRanks[role] > 0
+}
+
+// User is an account that can sign in to change the catalog
+type User struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	Email        string     `gorm:"not null;uniqueIndex;size:255" json:"email" example:"editor@example.com"` // stored lower-cased
+	Name         string     `json:"name" example:"Jane Editor"`
+	Role         string     `gorm:"not null;default:viewer;size:20;index" json:"role" example:"editor"`
+	PasswordHash string     `gorm:"not null" json:"-"`
+	LastLoginAt  *time.Time `json:"last_login_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (User) TableName() string {
+	return "users"
+}
+
+// RefreshToken is a single-use token for getting a new access token. Tokens
+// issued from one login form a family; presenting a token that was already
+// used revokes the whole family, since one of the copies was stolen.
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey"`
+	UserID    uint       `gorm:"not null;index"`
+	TokenHash string     `gorm:"not null;uniqueIndex;size:64"` // SHA-256 of the token, hex encoded
+	FamilyID  string     `gorm:"not null;index;size:36"`
+	ExpiresAt time.Time  `gorm:"not null;index"`
+	RevokedAt *time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// AuthTokens is the response of login and refresh
+type AuthTokens struct {
+	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string `json:"refresh_token" example:"q3Jd9X0wV1nH2kS8b7yZ4uT6rE5cA1mP0oL9iK8jH7g"`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int64  `json:"expires_in" example:"900"` // seconds until the access token expires
+	User         *User  `json:"user"`
+}
+
+// Identity is the authenticated caller of a request: a user signed in with
+// an access token, or a client sending an API key
+type Identity struct {
+	UserID uint
+	Email  string
+	Role   string // empty for API keys, which are limited by their scopes
+	APIKey *APIKey
+}
+
+// Name identifies the caller in revisions and logs
+func (i *Identity) Name() string {
+	if i.APIKey != nil {
+		return "api-key:" + i.APIKey.Name
+	}
+	return i.Email
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Personal movie lists of a user
+const (
+	UserListWatchlist = "watchlist"
+	UserListFavorites = "favorites"
+	UserListWatched   = "watched"
+)
+
+// UserMovie is what a user saved about a movie: whether it is on their
+// watchlist, a favorite, and when they watched it. The row is removed once
+// it is on none of the lists.
+type UserMovie struct {
+	UserID        uint       `gorm:"primaryKey" json:"user_id"`
+	MovieID       uint       `gorm:"primaryKey;index" json:"movie_id"`
+	InWatchlist   bool       `gorm:"not null;default:false" json:"in_watchlist"`
+	WatchlistedAt *time.Time `json:"watchlisted_at,omitempty"`
+	IsFavorite    bool       `gorm:"not null;default:false" json:"is_favorite"`
+	FavoritedAt   *time.Time `json:"favorited_at,omitempty"`
+	WatchedAt     *time.Time `json:"watched_at,omitempty"` // nil when not watched
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func (UserMovie) TableName() string {
+	return "user_movies"
+}
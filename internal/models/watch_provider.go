@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// Watch provider monetization types synced from TMDB
+const (
+	MonetizationFlatrate = "flatrate"
+	MonetizationRent     = "rent"
+	MonetizationBuy      = "buy"
+)
+
+// WatchProvider is a streaming service or store, e.g. Netflix or Apple TV
+type WatchProvider struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	TMDBID          int       `gorm:"uniqueIndex;not null" json:"tmdb_id" example:"8"`
+	Name            string    `gorm:"not null" json:"name" example:"Netflix"`
+	LogoPath        string    `json:"logo_path" example:"/t2yyOv40HZeVlLjYsCsPHnWLk4W.jpg"`
+	DisplayPriority int       `json:"display_priority" example:"3"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func (WatchProvider) TableName() string {
+	return "watch_providers"
+}
+
+// MovieWatchProvider records that a movie can be watched with a provider in a
+// region, by subscription (flatrate), rental or purchase
+type MovieWatchProvider struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	MovieID    uint           `gorm:"not null;uniqueIndex:idx_movie_watch_provider" json:"movie_id"`
+	ProviderID uint           `gorm:"not null;uniqueIndex:idx_movie_watch_provider;index" json:"provider_id"`
+	Region     string         `gorm:"not null;size:2;uniqueIndex:idx_movie_watch_provider;index" json:"region" example:"US"` // ISO 3166-1
+	Type       string         `gorm:"not null;size:20;uniqueIndex:idx_movie_watch_provider" json:"type" example:"flatrate"`
+	Link       string         `json:"link,omitempty"` // TMDB watch page of the movie in the region
+	Provider   *WatchProvider `gorm:"foreignKey:ProviderID" json:"provider,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+func (MovieWatchProvider) TableName() string {
+	return "movie_watch_providers"
+}
+
+// MovieRegionProviders lists where a movie can be watched in one region
+type MovieRegionProviders struct {
+	Region   string          `json:"region" example:"US"`
+	Link     string          `json:"link,omitempty" example:"https://www.themoviedb.org/movie/550-fight-club/watch?locale=US"`
+	Flatrate []WatchProvider `json:"flatrate"`
+	Rent     []WatchProvider `json:"rent"`
+	Buy      []WatchProvider `json:"buy"`
+}
+
+// ProviderCoverage is the number and share of live catalog movies available
+// with a provider
+type ProviderCoverage struct {
+	ProviderID uint    `json:"provider_id" example:"1"`
+	Name       string  `json:"name" example:"Netflix"`
+	LogoPath   string  `json:"logo_path" example:"/t2yyOv40HZeVlLjYsCsPHnWLk4W.jpg"`
+	MovieCount int64   `json:"movie_count" example:"42"`
+	Coverage   float64 `json:"coverage" example:"12.5"` // percentage of live movies
+}
+
+// TMDBWatchProvider is a provider entry of /movie/{id}/watch/providers
+type TMDBWatchProvider struct {
+	ProviderID      int    `json:"provider_id"`
+	ProviderName    string `json:"provider_name"`
+	LogoPath        string `json:"logo_path"`
+	DisplayPriority int    `json:"display_priority"`
+}
+
+// TMDBWatchProvidersResponse is the response of /movie/{id}/watch/providers,
+// keyed by ISO 3166-1 country
+type TMDBWatchProvidersResponse struct {
+	ID      int `json:"id"`
+	Results map[string]struct {
+		Link     string              `json:"link"`
+		Flatrate []TMDBWatchProvider `json:"flatrate"`
+		Rent     []TMDBWatchProvider `json:"rent"`
+		Buy      []TMDBWatchProvider `json:"buy"`
+	} `json:"results"`
+}
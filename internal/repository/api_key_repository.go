@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"movie-backend/internal/database"
+	"movie-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	FindAll(ctx context.Context) ([]models.APIKey, error)
+	FindByID(ctx context.Context, id uint) (*models.APIKey, error)
+	FindByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	// TouchLastUsed records a use of the key, unless one was recorded after
+	// the given time, so busy keys don't write on every request
+	TouchLastUsed(ctx context.Context, id uint, at, unlessAfter time.Time) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type apiKeyRepository struct {
+	db      *database.Database
+	timeout time.Duration
+}
+
+func NewAPIKeyRepository(db *database.Database) APIKeyRepository {
+	return &apiKeyRepository{
+		db:      db,
+		timeout: db.GetQueryTimeout(),
+	}
+}
+
+func (r *apiKeyRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, r.timeout)
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+func (r *apiKeyRepository) FindAll(ctx context.Context) ([]models.APIKey, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var keys []models.APIKey
+	err := r.db.WithContext(ctx).Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *apiKeyRepository) FindByID(ctx context.Context, id uint) (*models.APIKey, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var key models.APIKey
+	err := r.db.WithContext(ctx).First(&key, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var key models.APIKey
+	err := r.db.WithContext(ctx).Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uint, at, unlessAfter time.Time) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&models.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, unlessAfter).
+		UpdateColumn("last_used_at", at).Error
+}
+
+func (r *apiKeyRepository) Delete(ctx context.Context, id uint) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Delete(&models.APIKey{}, id).Error
+}
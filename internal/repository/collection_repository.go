@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"movie-backend/internal/database"
+	"movie-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CollectionRepository interface {
+	Upsert(ctx context.Context, collection *models.Collection) error
+	FindAllWithStats(ctx context.Context, search string, page, limit int) ([]models.CollectionWithStats, int64, error)
+	FindByIDWithStats(ctx context.Context, id uint) (*models.CollectionWithStats, error)
+}
+
+// collectionStatsSelect aggregates the live movies joined to collections.
+// Release dates are YYYY-MM-DD strings, so the year is their first four characters.
+const collectionStatsSelect = `collections.*,
+	COUNT(movies.id) AS movie_count,
+	COALESCE(AVG(movies.vote_average), 0) AS average_rating,
+	MIN(CASE WHEN LENGTH(movies.release_date) >= 4 THEN SUBSTRING(movies.release_date, 1, 4)::int END) AS first_year,
+	MAX(CASE WHEN LENGTH(movies.release_date) >= 4 THEN SUBSTRING(movies.release_date, 1, 4)::int END) AS last_year`
+
+type collectionRepository struct {
+	db      *database.Database
+	timeout time.Duration
+}
+
+func NewCollectionRepository(db *database.Database) CollectionRepository {
+	return &collectionRepository{
+		db:      db,
+		timeout: db.GetQueryTimeout(),
+	}
+}
+
+func (r *collectionRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, r.timeout)
+}
+
+// Upsert creates the collection or refreshes the stored one with the same
+// TMDB ID, and sets collection.ID either way
+func (r *collectionRepository) Upsert(ctx context.Context, collection *models.Collection) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tmdb_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "overview", "poster_path", "backdrop_path", "updated_at"}),
+	}).Create(collection).Error
+}
+
+func (r *collectionRepository) FindAllWithStats(ctx context.Context, search string, page, limit int) ([]models.CollectionWithStats, int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Model(&models.Collection{})
+	if search != "" {
+		query = query.Where("collections.name ILIKE ?", "%"+search+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var collections []models.CollectionWithStats
+	err := r.statsQuery(query).
+		Order("collections.name ASC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Scan(&collections).Error
+	return collections, total, err
+}
+
+func (r *collectionRepository) FindByIDWithStats(ctx context.Context, id uint) (*models.CollectionWithStats, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var collection models.CollectionWithStats
+	err := r.statsQuery(r.db.WithContext(ctx).Model(&models.Collection{}).Where("collections.id = ?", id)).
+		Take(&collection).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &collection, nil
+}
+
+func (r *collectionRepository) statsQuery(query *gorm.DB) *gorm.DB {
+	return query.
+		Select(collectionStatsSelect).
+		Joins("LEFT JOIN movies ON movies.collection_id = collections.id AND movies.deleted_at IS NULL").
+		Group("collections.id")
+}
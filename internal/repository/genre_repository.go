@@ -3,19 +3,27 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"movie-backend/internal/database"
 	"movie-backend/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GenreRepository interface {
 	Create(ctx context.Context, genre *models.Genre) error
 	FindByTMDBID(ctx context.Context, tmdbID int) (*models.Genre, error)
+	FindByName(ctx context.Context, name string) (*models.Genre, error)
 	FindOrCreate(ctx context.Context, tmdbID int, name string) (*models.Genre, error)
 	FindAll(ctx context.Context) ([]models.Genre, error)
+	FindByID(ctx context.Context, id uint) (*models.Genre, error)
+	FindAllWithMovieCount(ctx context.Context) ([]models.GenreWithCount, error)
+	CountMovies(ctx context.Context, id uint) (int64, error)
+	Update(ctx context.Context, genre *models.Genre) error
+	Merge(ctx context.Context, sourceID, targetID uint) error
 }
 
 type genreRepository struct {
@@ -44,12 +52,30 @@ func (r *genreRepository) Create(ctx context.Context, genre *models.Genre) error
 	return r.db.WithContext(ctx).Create(genre).Error
 }
 
+// tmdbIDCondition matches the genre with a TMDB ID, or the genre it was merged into
+const tmdbIDCondition = "tmdb_id = ? OR id = (SELECT genre_id FROM genre_aliases WHERE tmdb_id = ?)"
+
 func (r *genreRepository) FindByTMDBID(ctx context.Context, tmdbID int) (*models.Genre, error) {
 	ctx, cancel := r.withTimeout(ctx)
 	defer cancel()
 
 	var genre models.Genre
-	err := r.db.WithContext(ctx).Where("tmdb_id = ?", tmdbID).First(&genre).Error
+	err := r.db.WithContext(ctx).Where(tmdbIDCondition, tmdbID, tmdbID).First(&genre).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &genre, nil
+}
+
+func (r *genreRepository) FindByName(ctx context.Context, name string) (*models.Genre, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var genre models.Genre
+	err := r.db.WithContext(ctx).Where("LOWER(name) = LOWER(?)", name).First(&genre).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -64,8 +90,8 @@ func (r *genreRepository) FindOrCreate(ctx context.Context, tmdbID int, name str
 	defer cancel()
 
 	var genre models.Genre
-	err := r.db.WithContext(ctx).Where("tmdb_id = ?", tmdbID).FirstOrCreate(&genre, models.Genre{
-		TMDBID: tmdbID,
+	err := r.db.WithContext(ctx).Where(tmdbIDCondition, tmdbID, tmdbID).FirstOrCreate(&genre, models.Genre{
+		TMDBID: &tmdbID,
 		Name:   name,
 	}).Error
 	if err != nil {
@@ -82,3 +108,125 @@ func (r *genreRepository) FindAll(ctx context.Context) ([]models.Genre, error) {
 	err := r.db.WithContext(ctx).Find(&genres).Error
 	return genres, err
 }
+
+func (r *genreRepository) FindByID(ctx context.Context, id uint) (*models.Genre, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var genre models.Genre
+	err := r.db.WithContext(ctx).First(&genre, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &genre, nil
+}
+
+func (r *genreRepository) FindAllWithMovieCount(ctx context.Context) ([]models.GenreWithCount, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var genres []models.GenreWithCount
+	err := r.db.WithContext(ctx).
+		Table("genres").
+		Select("genres.*, COUNT(DISTINCT movies.id) AS movie_count").
+		Joins("LEFT JOIN movie_genres ON movie_genres.genre_id = genres.id").
+		Joins("LEFT JOIN movies ON movies.id = movie_genres.movie_id AND movies.deleted_at IS NULL").
+		Group("genres.id").
+		Order("genres.name ASC").
+		Scan(&genres).Error
+	return genres, err
+}
+
+func (r *genreRepository) CountMovies(ctx context.Context, id uint) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Movie{}).
+		Where("id IN (SELECT movie_id FROM movie_genres WHERE genre_id = ?)", id).
+		Count(&count).Error
+	return count, err
+}
+
+// Update saves the genre and bumps the version of its movies, whose
+// responses embed the genre
+func (r *genreRepository) Update(ctx context.Context, genre *models.Genre) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.Transaction(ctx, func(tx *database.Database) error {
+		if err := tx.Save(genre).Error; err != nil {
+			return err
+		}
+		return bumpGenreMovies(tx, genre.ID)
+	})
+}
+
+// Merge moves every movie of the source genre to the target genre and deletes
+// the source. Movies already tagged with the target keep a single row, and
+// every movie of the source gets a new version. The target inherits the
+// source's TMDB ID when it has none; otherwise the source's TMDB ID becomes an
+// alias of the target. Either way later syncs keep mapping that TMDB genre to
+// the target.
+func (r *genreRepository) Merge(ctx context.Context, sourceID, targetID uint) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.Transaction(ctx, func(tx *database.Database) error {
+		var source, target models.Genre
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&source, sourceID).Error; err != nil {
+			return fmt.Errorf("failed to load source genre: %w", err)
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&target, targetID).Error; err != nil {
+			return fmt.Errorf("failed to load target genre: %w", err)
+		}
+
+		// The genres of the source's movies change, whether they move to the
+		// target or already had it
+		if err := bumpGenreMovies(tx, sourceID); err != nil {
+			return err
+		}
+		if err := tx.Exec(`UPDATE movie_genres SET genre_id = ?
+			WHERE genre_id = ? AND movie_id NOT IN (SELECT movie_id FROM movie_genres WHERE genre_id = ?)`,
+			targetID, sourceID, targetID).Error; err != nil {
+			return fmt.Errorf("failed to move movie genres: %w", err)
+		}
+		if err := tx.Where("genre_id = ?", sourceID).Delete(&models.MovieGenre{}).Error; err != nil {
+			return fmt.Errorf("failed to delete duplicate movie genres: %w", err)
+		}
+
+		if err := tx.Delete(&source).Error; err != nil {
+			return fmt.Errorf("failed to delete source genre: %w", err)
+		}
+		if err := tx.Model(&models.GenreAlias{}).Where("genre_id = ?", sourceID).Update("genre_id", targetID).Error; err != nil {
+			return fmt.Errorf("failed to move genre aliases: %w", err)
+		}
+		switch {
+		case source.TMDBID == nil:
+		case target.TMDBID == nil:
+			if err := tx.Model(&target).Update("tmdb_id", *source.TMDBID).Error; err != nil {
+				return fmt.Errorf("failed to move TMDB ID to target genre: %w", err)
+			}
+		default:
+			if err := tx.Create(&models.GenreAlias{TMDBID: *source.TMDBID, GenreID: targetID}).Error; err != nil {
+				return fmt.Errorf("failed to alias TMDB ID to target genre: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// bumpGenreMovies bumps the version of every movie in the genre, trashed ones
+// included. Movie ETags are built from the version, so without it clients
+// would keep revalidating the old genres and pass If-Match against them.
+func bumpGenreMovies(tx *database.Database, genreID uint) error {
+	if err := tx.Exec("UPDATE movies SET version = version + 1, updated_at = ? WHERE id IN (SELECT movie_id FROM movie_genres WHERE genre_id = ?)",
+		time.Now(), genreID).Error; err != nil {
+		return fmt.Errorf("failed to bump movie versions: %w", err)
+	}
+	return nil
+}
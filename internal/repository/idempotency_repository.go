@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"movie-backend/internal/database"
+	"movie-backend/internal/models"
+
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepository interface {
+	// Reserve inserts the record unless a live record with the same actor and
+	// key exists, in which case that record is returned instead
+	Reserve(ctx context.Context, record *models.IdempotencyKey) (bool, *models.IdempotencyKey, error)
+	Complete(ctx context.Context, actor, key string, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, actor, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db      *database.Database
+	timeout time.Duration
+}
+
+func NewIdempotencyRepository(db *database.Database) IdempotencyRepository {
+	return &idempotencyRepository{
+		db:      db,
+		timeout: db.GetQueryTimeout(),
+	}
+}
+
+func (r *idempotencyRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, r.timeout)
+}
+
+func (r *idempotencyRepository) Reserve(ctx context.Context, record *models.IdempotencyKey) (bool, *models.IdempotencyKey, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	db := r.db.WithContext(ctx)
+
+	// An expired record no longer blocks the key
+	if err := db.Where("actor = ? AND idempotency_key = ? AND expires_at < ?", record.Actor, record.Key, time.Now().UTC()).
+		Delete(&models.IdempotencyKey{}).Error; err != nil {
+		return false, nil, err
+	}
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return false, nil, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return true, nil, nil
+	}
+
+	var existing models.IdempotencyKey
+	if err := db.Where("actor = ? AND idempotency_key = ?", record.Actor, record.Key).First(&existing).Error; err != nil {
+		return false, nil, err
+	}
+	return false, &existing, nil
+}
+
+func (r *idempotencyRepository) Complete(ctx context.Context, actor, key string, statusCode int, contentType string, body []byte) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&models.IdempotencyKey{}).
+		Where("actor = ? AND idempotency_key = ?", actor, key).
+		Updates(map[string]interface{}{
+			"status":        models.IdempotencyStatusCompleted,
+			"status_code":   statusCode,
+			"content_type":  contentType,
+			"response_body": body,
+		}).Error
+}
+
+func (r *idempotencyRepository) Release(ctx context.Context, actor, key string) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Where("actor = ? AND idempotency_key = ?", actor, key).Delete(&models.IdempotencyKey{}).Error
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Where("expires_at < ?", time.Now().UTC()).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -9,13 +9,21 @@ import (
 	"movie-backend/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type LanguageRepository interface {
 	Create(ctx context.Context, language *models.Language) error
 	FindByCode(ctx context.Context, code string) (*models.Language, error)
+	FindByName(ctx context.Context, name string) (*models.Language, error)
 	FindOrCreate(ctx context.Context, code, name string) (*models.Language, error)
 	FindAll(ctx context.Context) ([]models.Language, error)
+	FindByID(ctx context.Context, id uint) (*models.Language, error)
+	FindAllWithMovieCount(ctx context.Context) ([]models.LanguageWithCount, error)
+	CountMovies(ctx context.Context, id uint) (int64, error)
+	Update(ctx context.Context, language *models.Language) error
+	UpsertTranslation(ctx context.Context, translation *models.LanguageTranslation) error
+	DeleteTranslation(ctx context.Context, languageID uint, locale string) (bool, error)
 }
 
 type languageRepository struct {
@@ -59,6 +67,21 @@ func (r *languageRepository) FindByCode(ctx context.Context, code string) (*mode
 	return &language, nil
 }
 
+func (r *languageRepository) FindByName(ctx context.Context, name string) (*models.Language, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var language models.Language
+	err := r.db.WithContext(ctx).Where("LOWER(name) = LOWER(?)", name).First(&language).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &language, nil
+}
+
 func (r *languageRepository) FindOrCreate(ctx context.Context, code, name string) (*models.Language, error) {
 	ctx, cancel := r.withTimeout(ctx)
 	defer cancel()
@@ -82,3 +105,86 @@ func (r *languageRepository) FindAll(ctx context.Context) ([]models.Language, er
 	err := r.db.WithContext(ctx).Find(&languages).Error
 	return languages, err
 }
+
+func (r *languageRepository) FindByID(ctx context.Context, id uint) (*models.Language, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var language models.Language
+	err := r.db.WithContext(ctx).Preload("Translations", func(db *gorm.DB) *gorm.DB {
+		return db.Order("locale ASC")
+	}).First(&language, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &language, nil
+}
+
+func (r *languageRepository) FindAllWithMovieCount(ctx context.Context) ([]models.LanguageWithCount, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var languages []models.LanguageWithCount
+	err := r.db.WithContext(ctx).
+		Table("languages").
+		Select("languages.*, COUNT(movies.id) AS movie_count").
+		Joins("LEFT JOIN movies ON movies.language_id = languages.id AND movies.deleted_at IS NULL").
+		Group("languages.id").
+		Order("movie_count DESC, languages.name ASC").
+		Scan(&languages).Error
+	if err != nil || len(languages) == 0 {
+		return languages, err
+	}
+
+	var translations []models.LanguageTranslation
+	if err := r.db.WithContext(ctx).Order("locale ASC").Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	byLanguage := make(map[uint][]models.LanguageTranslation)
+	for _, translation := range translations {
+		byLanguage[translation.LanguageID] = append(byLanguage[translation.LanguageID], translation)
+	}
+	for i := range languages {
+		languages[i].Translations = byLanguage[languages[i].ID]
+	}
+	return languages, nil
+}
+
+func (r *languageRepository) CountMovies(ctx context.Context, id uint) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Movie{}).Where("language_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (r *languageRepository) Update(ctx context.Context, language *models.Language) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(language).Update("name", language.Name).Error
+}
+
+func (r *languageRepository) UpsertTranslation(ctx context.Context, translation *models.LanguageTranslation) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "language_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "updated_at"}),
+	}).Create(translation).Error
+}
+
+func (r *languageRepository) DeleteTranslation(ctx context.Context, languageID uint, locale string) (bool, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Where("language_id = ? AND locale = ?", languageID, locale).
+		Delete(&models.LanguageTranslation{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -3,36 +3,90 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"time"
 
+	"movie-backend/internal/constants"
 	"movie-backend/internal/database"
 	"movie-backend/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MovieRepository interface {
 	// CRUD operations
 	Create(ctx context.Context, movie *models.Movie) error
 	Update(ctx context.Context, movie *models.Movie) error
-	Delete(ctx context.Context, id uint) error
+	Delete(ctx context.Context, id uint, version int64) error
+	Restore(ctx context.Context, id uint) (bool, error)
+	FindPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]models.Movie, error)
+	// Purge permanently deletes a movie that is still in the trash and was
+	// deleted before deletedBefore, and returns it. It returns
+	// ErrMovieNotPurgeable if the movie was restored or deleted again since
+	// it was listed, and leaves it untouched.
+	Purge(ctx context.Context, id uint, deletedBefore time.Time) (*models.Movie, error)
 	FindByID(ctx context.Context, id uint) (*models.Movie, error)
+	FindByIDWithProjection(ctx context.Context, id uint, projection models.MovieProjection) (*models.Movie, error)
 	FindByTMDBID(ctx context.Context, tmdbID int) (*models.Movie, error)
-	FindAll(ctx context.Context, page, limit int, search, sortBy, order, startDate, endDate string) ([]models.Movie, int64, error)
+	FindAll(ctx context.Context, filter models.MovieFilter, projection models.MovieProjection) ([]models.Movie, int64, error)
+	StreamForExport(ctx context.Context, filter models.MovieFilter, fn func(row *models.MovieExportRow) error) error
+	ReplaceGenres(ctx context.Context, movie *models.Movie, genres []models.Genre) error
+	// FindOrCreateLanguage finds the language with code or creates it. Inside
+	// Transaction a created language is rolled back with the transaction.
+	FindOrCreateLanguage(ctx context.Context, code, name string) (*models.Language, error)
+	UpsertTranslations(ctx context.Context, translations []models.MovieTranslation) error
+	ReplaceVideos(ctx context.Context, movieID uint, videos []models.MovieVideo) error
+	FindVideos(ctx context.Context, movieID uint, types []string) ([]models.MovieVideo, error)
+	ReplaceReleaseDates(ctx context.Context, movieID uint, releaseDates []models.MovieReleaseDate) error
+	FindReleaseDates(ctx context.Context, movieID uint, country string) ([]models.MovieReleaseDate, error)
+
+	// Transaction runs fn with a repository bound to a single database transaction
+	Transaction(ctx context.Context, fn func(repo MovieRepository) error) error
 
 	// Dashboard operations
 	GetDashboardStats(ctx context.Context) (*models.DashboardStats, error)
 
+	// Catalog version operations, used for HTTP cache validation
+	GetCatalogState(ctx context.Context) (*models.CatalogState, error)
+	BumpCatalogVersion(ctx context.Context) error
+
+	// Revision operations
+	CreateRevision(ctx context.Context, revision *models.MovieRevision) error
+	FindRevisions(ctx context.Context, movieID uint, page, limit int) ([]models.MovieRevision, int64, error)
+	FindRevision(ctx context.Context, movieID uint, revision int) (*models.MovieRevision, error)
+
+	// Field lock operations
+	FindFieldLocks(ctx context.Context, movieID uint) ([]models.MovieFieldLock, error)
+	LockFields(ctx context.Context, movieID uint, fields []string, lockedBy string) error
+	UnlockField(ctx context.Context, movieID uint, field string) (bool, error)
+
 	// Sync log operations
 	CreateSyncLog(ctx context.Context, log *models.SyncLog) error
 	GetLastSyncLog(ctx context.Context) (*models.SyncLog, error)
 
 	// Chart data operations
 	GetMoviesByLanguage(ctx context.Context) ([]models.PieChartData, error)
-	GetMoviesByYear(ctx context.Context, startDate, endDate string) ([]models.ColumnChartData, error)
-	GetMoviesByMonth(ctx context.Context, year int) ([]models.ColumnChartData, error)
+	GetMoviesByYear(ctx context.Context, startDate, endDate, region string) ([]models.ColumnChartData, error)
+	GetMoviesByMonth(ctx context.Context, year int, region string) ([]models.ColumnChartData, error)
 }
 
+// ErrMovieNotFound is returned when a movie does not exist or is in the trash
+var ErrMovieNotFound = errors.New("movie not found")
+
+// ErrMovieNotPurgeable is returned when purging a movie that is no longer
+// expired in the trash
+var ErrMovieNotPurgeable = errors.New("movie is no longer expired in the trash")
+
+// ErrVersionConflict is returned when a movie was changed by another write
+// since the version the caller based its write on
+var ErrVersionConflict = errors.New("movie was modified by another request")
+
+// catalogStateID is the primary key of the single catalog_state row
+const catalogStateID = 1
+
 type movieRepository struct {
 	db      *database.Database
 	timeout time.Duration
@@ -59,18 +113,259 @@ func (r *movieRepository) Create(ctx context.Context, movie *models.Movie) error
 	return r.db.WithContext(ctx).Create(movie).Error
 }
 
+// Update saves all columns of the movie and bumps its version, provided the
+// stored version still equals movie.Version. Otherwise nothing is written and
+// ErrVersionConflict is returned. Genres are added like Save would.
 func (r *movieRepository) Update(ctx context.Context, movie *models.Movie) error {
 	ctx, cancel := r.withTimeout(ctx)
 	defer cancel()
 
-	return r.db.WithContext(ctx).Save(movie).Error
+	version := movie.Version
+	movie.Version = version + 1
+	result := r.db.WithContext(ctx).Model(movie).
+		Select("*").Omit("id", "created_at", "deleted_at", clause.Associations).
+		Where("version = ?", version).
+		Updates(movie)
+	if result.Error != nil || result.RowsAffected == 0 {
+		movie.Version = version
+		if result.Error != nil {
+			return result.Error
+		}
+		return ErrVersionConflict
+	}
+
+	if len(movie.Genres) == 0 {
+		return nil
+	}
+	// Append adds to movie.Genres, so hand it the genres on an empty slice
+	genres := movie.Genres
+	movie.Genres = nil
+	return r.db.WithContext(ctx).Model(movie).Association("Genres").Append(genres)
+}
+
+// Delete moves a movie to the trash by setting deleted_at, provided it still
+// has the given version
+func (r *movieRepository) Delete(ctx context.Context, id uint, version int64) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Model(&models.Movie{}).
+		Where("id = ? AND version = ?", id, version).
+		Updates(map[string]interface{}{"deleted_at": time.Now(), "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// Restore takes a movie out of the trash and reports whether it was trashed
+func (r *movieRepository) Restore(ctx context.Context, id uint) (bool, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Unscoped().Model(&models.Movie{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "updated_at": time.Now(), "version": gorm.Expr("version + 1")})
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindPurgeable returns trashed movies deleted before the given time, oldest first
+func (r *movieRepository) FindPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]models.Movie, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var movies []models.Movie
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Find(&movies).Error
+	return movies, err
+}
+
+// Purge permanently deletes a trashed movie with its genre links and translations.
+// Its revisions are kept as the audit trail of the movie.
+// The movie row is locked and checked first, so a concurrent restore either
+// waits for the purge or makes it delete nothing.
+func (r *movieRepository) Purge(ctx context.Context, id uint, deletedBefore time.Time) (*models.Movie, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var movie models.Movie
+	err := r.db.Transaction(ctx, func(tx *database.Database) error {
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NOT NULL AND deleted_at < ?", id, deletedBefore).
+			First(&movie).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrMovieNotPurgeable
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock movie: %w", err)
+		}
+
+		if err := tx.Where("movie_id = ?", id).Delete(&models.MovieGenre{}).Error; err != nil {
+			return fmt.Errorf("failed to delete movie genres: %w", err)
+		}
+		if err := tx.Where("movie_id = ?", id).Delete(&models.MovieTranslation{}).Error; err != nil {
+			return fmt.Errorf("failed to delete movie translations: %w", err)
+		}
+		if err := tx.Exec("DELETE FROM movie_keywords WHERE movie_id = ?", id).Error; err != nil {
+			return fmt.Errorf("failed to delete movie keywords: %w", err)
+		}
+		if err := tx.Exec("DELETE FROM movie_tags WHERE movie_id = ?", id).Error; err != nil {
+			return fmt.Errorf("failed to delete movie tags: %w", err)
+		}
+		if err := tx.Where("movie_id = ?", id).Delete(&models.MovieVideo{}).Error; err != nil {
+			return fmt.Errorf("failed to delete movie videos: %w", err)
+		}
+		if err := tx.Where("movie_id = ?", id).Delete(&models.MovieReleaseDate{}).Error; err != nil {
+			return fmt.Errorf("failed to delete movie release dates: %w", err)
+		}
+		if err := tx.Where("movie_id = ?", id).Delete(&models.MovieWatchProvider{}).Error; err != nil {
+			return fmt.Errorf("failed to delete movie watch providers: %w", err)
+		}
+		if err := tx.Where("movie_id = ?", id).Delete(&models.MovieFieldLock{}).Error; err != nil {
+			return fmt.Errorf("failed to delete movie field locks: %w", err)
+		}
+		if err := tx.Where("movie_id = ?", id).Delete(&models.UserMovie{}).Error; err != nil {
+			return fmt.Errorf("failed to delete user movie lists: %w", err)
+		}
+		if err := tx.Where("movie_id = ?", id).Delete(&models.MovieReview{}).Error; err != nil {
+			return fmt.Errorf("failed to delete movie reviews: %w", err)
+		}
+		result := tx.Unscoped().Where("deleted_at IS NOT NULL").Delete(&models.Movie{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrMovieNotPurgeable
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &movie, nil
 }
 
-func (r *movieRepository) Delete(ctx context.Context, id uint) error {
+func (r *movieRepository) ReplaceGenres(ctx context.Context, movie *models.Movie, genres []models.Genre) error {
 	ctx, cancel := r.withTimeout(ctx)
 	defer cancel()
 
-	return r.db.WithContext(ctx).Delete(&models.Movie{}, id).Error
+	return r.db.WithContext(ctx).Model(movie).Association("Genres").Replace(genres)
+}
+
+func (r *movieRepository) FindOrCreateLanguage(ctx context.Context, code, name string) (*models.Language, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var language models.Language
+	err := r.db.WithContext(ctx).Where("code = ?", code).FirstOrCreate(&language, models.Language{
+		Code: code,
+		Name: name,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+	return &language, nil
+}
+
+func (r *movieRepository) UpsertTranslations(ctx context.Context, translations []models.MovieTranslation) error {
+	if len(translations) == 0 {
+		return nil
+	}
+
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "movie_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "overview", "tagline", "updated_at"}),
+	}).Create(&translations).Error
+}
+
+// ReplaceVideos replaces the stored videos of a movie with the given ones
+func (r *movieRepository) ReplaceVideos(ctx context.Context, movieID uint, videos []models.MovieVideo) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.Transaction(ctx, func(tx *database.Database) error {
+		if err := tx.Where("movie_id = ?", movieID).Delete(&models.MovieVideo{}).Error; err != nil {
+			return err
+		}
+		if len(videos) == 0 {
+			return nil
+		}
+		for i := range videos {
+			videos[i].MovieID = movieID
+		}
+		return tx.Create(&videos).Error
+	})
+}
+
+// FindVideos returns the videos of a movie, official and newest first. Types
+// are matched case-insensitively; none returns every type.
+func (r *movieRepository) FindVideos(ctx context.Context, movieID uint, types []string) ([]models.MovieVideo, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Where("movie_id = ?", movieID)
+	if len(types) > 0 {
+		lowered := make([]string, len(types))
+		for i, t := range types {
+			lowered[i] = strings.ToLower(t)
+		}
+		query = query.Where("LOWER(type) IN ?", lowered)
+	}
+
+	var videos []models.MovieVideo
+	err := query.Order("official DESC, published_at DESC NULLS LAST, id ASC").Find(&videos).Error
+	return videos, err
+}
+
+// ReplaceReleaseDates replaces the stored release dates of a movie with the
+// given ones
+func (r *movieRepository) ReplaceReleaseDates(ctx context.Context, movieID uint, releaseDates []models.MovieReleaseDate) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.Transaction(ctx, func(tx *database.Database) error {
+		if err := tx.Where("movie_id = ?", movieID).Delete(&models.MovieReleaseDate{}).Error; err != nil {
+			return err
+		}
+		if len(releaseDates) == 0 {
+			return nil
+		}
+		for i := range releaseDates {
+			releaseDates[i].MovieID = movieID
+		}
+		return tx.Create(&releaseDates).Error
+	})
+}
+
+// FindReleaseDates returns the release dates of a movie by country and date,
+// optionally only those of one country
+func (r *movieRepository) FindReleaseDates(ctx context.Context, movieID uint, country string) ([]models.MovieReleaseDate, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Where("movie_id = ?", movieID)
+	if country != "" {
+		query = query.Where("country = ?", country)
+	}
+
+	var releaseDates []models.MovieReleaseDate
+	err := query.Order("country ASC, release_date ASC, type ASC").Find(&releaseDates).Error
+	return releaseDates, err
+}
+
+func (r *movieRepository) Transaction(ctx context.Context, fn func(repo MovieRepository) error) error {
+	return r.db.Transaction(ctx, func(tx *database.Database) error {
+		return fn(&movieRepository{db: tx, timeout: r.timeout})
+	})
 }
 
 func (r *movieRepository) FindByID(ctx context.Context, id uint) (*models.Movie, error) {
@@ -81,7 +376,22 @@ func (r *movieRepository) FindByID(ctx context.Context, id uint) (*models.Movie,
 	err := r.db.WithContext(ctx).Preload("Language").Preload("Genres").First(&movie, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("movie not found")
+			return nil, ErrMovieNotFound
+		}
+		return nil, err
+	}
+	return &movie, nil
+}
+
+func (r *movieRepository) FindByIDWithProjection(ctx context.Context, id uint, projection models.MovieProjection) (*models.Movie, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var movie models.Movie
+	err := applyMovieProjection(r.db.WithContext(ctx), projection).First(&movie, "movies.id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMovieNotFound
 		}
 		return nil, err
 	}
@@ -92,8 +402,9 @@ func (r *movieRepository) FindByTMDBID(ctx context.Context, tmdbID int) (*models
 	ctx, cancel := r.withTimeout(ctx)
 	defer cancel()
 
+	// Trashed movies are included so callers don't recreate a deleted TMDB ID
 	var movie models.Movie
-	err := r.db.WithContext(ctx).Where("tmdb_id = ?", tmdbID).First(&movie).Error
+	err := r.db.WithContext(ctx).Unscoped().Preload("Genres").Where("tmdb_id = ?", tmdbID).First(&movie).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -103,39 +414,214 @@ func (r *movieRepository) FindByTMDBID(ctx context.Context, tmdbID int) (*models
 	return &movie, nil
 }
 
-func (r *movieRepository) FindAll(ctx context.Context, page, limit int, search, sortBy, order, startDate, endDate string) ([]models.Movie, int64, error) {
+func (r *movieRepository) FindAll(ctx context.Context, filter models.MovieFilter, projection models.MovieProjection) ([]models.Movie, int64, error) {
 	ctx, cancel := r.withTimeout(ctx)
 	defer cancel()
 
 	var movies []models.Movie
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&models.Movie{})
+	query := applyMovieFilters(r.db.WithContext(ctx).Model(&models.Movie{}), filter)
+	if filter.Trashed {
+		query = query.Unscoped().Where("movies.deleted_at IS NOT NULL")
+	}
 
+	// Count total records
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = applyMovieProjection(query, projection).Order(movieOrder(filter.SortBy, filter.Order))
+
+	// Apply pagination
+	offset := (filter.Page - 1) * filter.Limit
+	if err := query.Offset(offset).Limit(filter.Limit).Find(&movies).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return movies, total, nil
+}
+
+// StreamForExport walks every movie matching the filters, with language and
+// genres flattened in, calling fn once per row without loading the result set
+// into memory
+func (r *movieRepository) StreamForExport(ctx context.Context, filter models.MovieFilter, fn func(row *models.MovieExportRow) error) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.ExportTimeout)
+	defer cancel()
+
+	db := r.db.WithContext(ctx)
+	query := applyMovieFilters(db.Model(&models.Movie{}), filter).
+		Select(`movies.id, movies.tmdb_id, movies.title, movies.original_title, movies.overview,
+			movies.release_date, movies.poster_path, movies.backdrop_path, movies.vote_average,
+			movies.vote_count, movies.popularity, movies.adult, movies.created_at, movies.updated_at,
+			COALESCE(languages.code, '') as language_code, COALESCE(languages.name, '') as language_name,
+			COALESCE((SELECT string_agg(genres.name, '|' ORDER BY genres.name)
+				FROM movie_genres JOIN genres ON genres.id = movie_genres.genre_id
+				WHERE movie_genres.movie_id = movies.id), '') as genres`).
+		Joins("LEFT JOIN languages ON movies.language_id = languages.id").
+		Order(movieOrder(filter.SortBy, filter.Order))
+
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.MovieExportRow
+		if err := db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// applyMovieFilters applies the search and release date filters shared by
+// the list and export queries
+func applyMovieFilters(query *gorm.DB, filter models.MovieFilter) *gorm.DB {
 	// Apply search filter
-	if search != "" {
-		searchPattern := "%" + search + "%"
-		query = query.Where("title ILIKE ? OR overview ILIKE ? OR original_title ILIKE ?",
-			searchPattern, searchPattern, searchPattern)
+	if filter.Search != "" {
+		searchPattern := "%" + filter.Search + "%"
+		query = query.Where("movies.title ILIKE ? OR movies.overview ILIKE ? OR movies.original_title ILIKE ? OR "+
+			"movies.id IN (SELECT movie_id FROM movie_translations WHERE title ILIKE ?)",
+			searchPattern, searchPattern, searchPattern, searchPattern)
 	}
 
-	// Apply date range filter
-	if startDate != "" {
-		query = query.Where("release_date >= ?", startDate)
+	// Apply date range filter. With a region the movie's earliest release in
+	// that country is used instead of its primary release date.
+	if filter.Region != "" && (filter.StartDate != "" || filter.EndDate != "") {
+		var having []string
+		args := []interface{}{filter.Region}
+		if filter.StartDate != "" {
+			having = append(having, "MIN(release_date) >= ?")
+			args = append(args, filter.StartDate)
+		}
+		if filter.EndDate != "" {
+			having = append(having, "MIN(release_date) <= ?")
+			args = append(args, filter.EndDate)
+		}
+		query = query.Where("movies.id IN (SELECT movie_id FROM release_dates WHERE country = ? GROUP BY movie_id HAVING "+
+			strings.Join(having, " AND ")+")", args...)
+	} else {
+		if filter.StartDate != "" {
+			query = query.Where("movies.release_date >= ?", filter.StartDate)
+		}
+		if filter.EndDate != "" {
+			query = query.Where("movies.release_date <= ?", filter.EndDate)
+		}
 	}
-	if endDate != "" {
-		query = query.Where("release_date <= ?", endDate)
+
+	// A movie passes the certification filter when it is rated in the region
+	// and none of its ratings there is more restrictive than the maximum
+	if filter.MaxCertification != "" {
+		allowed, _ := models.CertificationsUpTo(filter.Region, filter.MaxCertification)
+		for i := range allowed {
+			allowed[i] = strings.ToUpper(allowed[i])
+		}
+		query = query.Where("movies.id IN (SELECT movie_id FROM release_dates WHERE country = ? AND UPPER(certification) IN ?)",
+			filter.Region, allowed).
+			Where("NOT EXISTS (SELECT 1 FROM release_dates WHERE release_dates.movie_id = movies.id AND country = ? "+
+				"AND certification <> '' AND UPPER(certification) NOT IN ?)", filter.Region, allowed)
 	}
 
-	// Count total records
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if filter.GenreID > 0 {
+		query = query.Where("movies.id IN (SELECT movie_id FROM movie_genres WHERE genre_id = ?)", filter.GenreID)
+	}
+	if filter.CollectionID > 0 {
+		query = query.Where("movies.collection_id = ?", filter.CollectionID)
+	}
+	if filter.TagID > 0 {
+		query = query.Where("movies.id IN (SELECT movie_id FROM movie_tags WHERE tag_id = ?)", filter.TagID)
+	}
+	if filter.KeywordID > 0 {
+		query = query.Where("movies.id IN (SELECT movie_id FROM movie_keywords WHERE keyword_id = ?)", filter.KeywordID)
+	}
+	if filter.ProviderID > 0 {
+		if filter.Region != "" {
+			query = query.Where("movies.id IN (SELECT movie_id FROM movie_watch_providers WHERE provider_id = ? AND region = ?)",
+				filter.ProviderID, filter.Region)
+		} else {
+			query = query.Where("movies.id IN (SELECT movie_id FROM movie_watch_providers WHERE provider_id = ?)", filter.ProviderID)
+		}
 	}
+	if filter.UserID > 0 && filter.UserList != "" {
+		query = query.Where("movies.id IN (SELECT movie_id FROM user_movies WHERE user_id = ? AND "+userListCondition(filter.UserList)+")",
+			filter.UserID)
+	}
+	if filter.HasTrailer != nil {
+		trailers := "EXISTS (SELECT 1 FROM movie_videos WHERE movie_videos.movie_id = movies.id AND movie_videos.type = ?)"
+		if !*filter.HasTrailer {
+			trailers = "NOT " + trailers
+		}
+		query = query.Where(trailers, models.VideoTypeTrailer)
+	}
+
+	return query
+}
 
-	// Apply sorting with validation
+// applyMovieProjection selects only the requested columns and preloads only
+// the requested relations. The columns needed to load relations and to build
+// ETags are always selected.
+func applyMovieProjection(query *gorm.DB, projection models.MovieProjection) *gorm.DB {
+	if len(projection.Fields) > 0 {
+		selected := map[string]bool{}
+		for _, column := range models.MovieETagColumns {
+			selected[column] = true
+		}
+		if projection.Expands("language") {
+			selected["language_id"] = true
+		}
+		if projection.Expands("collection") {
+			selected["collection_id"] = true
+		}
+		for _, field := range projection.Fields {
+			selected[field] = true
+		}
+
+		columns := make([]string, 0, len(selected))
+		for _, column := range models.MovieFieldColumns {
+			if selected[column] {
+				columns = append(columns, "movies."+column)
+			}
+		}
+		query = query.Select(columns)
+	}
+
+	if projection.Expands("language") {
+		query = query.Preload("Language")
+	}
+	if projection.Expands("genres") {
+		query = query.Preload("Genres")
+	}
+	if projection.Expands("collection") {
+		query = query.Preload("Collection")
+	}
+	if projection.Expands("keywords") {
+		query = query.Preload("Keywords")
+	}
+	if projection.Expands("tags") {
+		query = query.Preload("Tags")
+	}
+	if len(projection.Locales) > 0 {
+		bases := make([]string, 0, len(projection.Locales))
+		for _, locale := range projection.Locales {
+			bases = append(bases, models.LocaleBase(locale))
+		}
+		query = query.Preload("Translations", "split_part(locale, '-', 1) IN ?", bases)
+	}
+	return query
+}
+
+// movieOrder returns a validated ORDER BY clause for movie queries
+func movieOrder(sortBy, order string) string {
 	validSortFields := map[string]bool{
 		"id": true, "title": true, "release_date": true, "vote_average": true,
-		"popularity": true, "created_at": true, "updated_at": true,
+		"popularity": true, "created_at": true, "updated_at": true, "deleted_at": true,
+		"user_rating": true,
 	}
 	if !validSortFields[sortBy] {
 		sortBy = "updated_at"
@@ -143,15 +629,11 @@ func (r *movieRepository) FindAll(ctx context.Context, page, limit int, search,
 	if order != "ASC" && order != "asc" {
 		order = "DESC"
 	}
-	query = query.Order(sortBy + " " + order)
-
-	// Apply pagination
-	offset := (page - 1) * limit
-	if err := query.Preload("Language").Preload("Genres").Offset(offset).Limit(limit).Find(&movies).Error; err != nil {
-		return nil, 0, err
+	if sortBy == "user_rating" {
+		// Among equal averages, more ratings rank higher
+		return "movies.user_rating_average " + order + ", movies.user_rating_count " + order
 	}
-
-	return movies, total, nil
+	return "movies." + sortBy + " " + order
 }
 
 func (r *movieRepository) GetDashboardStats(ctx context.Context) (*models.DashboardStats, error) {
@@ -215,9 +697,66 @@ func (r *movieRepository) GetDashboardStats(ctx context.Context) (*models.Dashbo
 		return nil, err
 	}
 
+	// Local community scores: the mean of all approved user ratings
+	// weighted by how many each movie has
+	type CommunityResult struct {
+		AvgRating    float64
+		TotalRatings int64
+		RatedMovies  int64
+	}
+	var community CommunityResult
+	if err := db.Model(&models.Movie{}).
+		Select("COALESCE(SUM(user_rating_average * user_rating_count) / NULLIF(SUM(user_rating_count), 0), 0) as avg_rating, " +
+			"COALESCE(SUM(user_rating_count), 0) as total_ratings, COUNT(*) FILTER (WHERE user_rating_count > 0) as rated_movies").
+		Scan(&community).Error; err != nil {
+		return nil, err
+	}
+	stats.AverageUserRating = math.Round(community.AvgRating*100) / 100
+	stats.TotalUserRatings = community.TotalRatings
+	stats.RatedMovies = community.RatedMovies
+
+	// Top rated by users (limit 10)
+	if err := db.Model(&models.Movie{}).
+		Preload("Language").Preload("Genres").
+		Where("user_rating_count >= ?", minUserRatingsForTopRated).
+		Order("user_rating_average DESC, user_rating_count DESC").
+		Limit(10).
+		Find(&stats.TopRatedByUsers).Error; err != nil {
+		return nil, err
+	}
+
 	return &stats, nil
 }
 
+func (r *movieRepository) GetCatalogState(ctx context.Context) (*models.CatalogState, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var state models.CatalogState
+	err := r.db.WithContext(ctx).First(&state, catalogStateID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &models.CatalogState{ID: catalogStateID}, nil
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (r *movieRepository) BumpCatalogVersion(ctx context.Context) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	state := models.CatalogState{ID: catalogStateID, Version: 1}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"version":    gorm.Expr("catalog_state.version + 1"),
+			"updated_at": time.Now().UTC(),
+		}),
+	}).Create(&state).Error
+}
+
 func (r *movieRepository) CreateSyncLog(ctx context.Context, log *models.SyncLog) error {
 	ctx, cancel := r.withTimeout(ctx)
 	defer cancel()
@@ -261,13 +800,25 @@ func (r *movieRepository) GetMoviesByLanguage(ctx context.Context) ([]models.Pie
 	return results, nil
 }
 
-func (r *movieRepository) GetMoviesByYear(ctx context.Context, startDate, endDate string) ([]models.ColumnChartData, error) {
+// releaseDateSource returns the rows the release date charts count: movies
+// by their primary release date or, with a region, live movies by their
+// earliest release in that country. Both expose a release_date column.
+func (r *movieRepository) releaseDateSource(ctx context.Context, region string) *gorm.DB {
+	if region == "" {
+		return r.db.WithContext(ctx).Model(&models.Movie{})
+	}
+	return r.db.WithContext(ctx).Table(`(SELECT release_dates.movie_id, MIN(release_dates.release_date) AS release_date
+		FROM release_dates JOIN movies ON movies.id = release_dates.movie_id AND movies.deleted_at IS NULL
+		WHERE release_dates.country = ? GROUP BY release_dates.movie_id) AS regional_releases`, region)
+}
+
+func (r *movieRepository) GetMoviesByYear(ctx context.Context, startDate, endDate, region string) ([]models.ColumnChartData, error) {
 	ctx, cancel := r.withTimeout(ctx)
 	defer cancel()
 
 	var results []models.ColumnChartData
 
-	query := r.db.WithContext(ctx).Model(&models.Movie{}).
+	query := r.releaseDateSource(ctx, region).
 		Select("SUBSTRING(release_date, 1, 4) as label, COUNT(*) as value").
 		Where("release_date != '' AND release_date IS NOT NULL AND LENGTH(release_date) >= 4")
 
@@ -290,7 +841,7 @@ func (r *movieRepository) GetMoviesByYear(ctx context.Context, startDate, endDat
 	return results, nil
 }
 
-func (r *movieRepository) GetMoviesByMonth(ctx context.Context, year int) ([]models.ColumnChartData, error) {
+func (r *movieRepository) GetMoviesByMonth(ctx context.Context, year int, region string) ([]models.ColumnChartData, error) {
 	ctx, cancel := r.withTimeout(ctx)
 	defer cancel()
 
@@ -306,7 +857,7 @@ func (r *movieRepository) GetMoviesByMonth(ctx context.Context, year int) ([]mod
 	var monthCounts []MonthCount
 	yearStr := string(rune('0'+year/1000)) + string(rune('0'+(year/100)%10)) + string(rune('0'+(year/10)%10)) + string(rune('0'+year%10))
 
-	err := r.db.WithContext(ctx).Model(&models.Movie{}).
+	err := r.releaseDateSource(ctx, region).
 		Select("CAST(SUBSTRING(release_date, 6, 2) AS INTEGER) as month, COUNT(*) as count").
 		Where("release_date LIKE ?", yearStr+"%").
 		Where("LENGTH(release_date) >= 7").
@@ -332,3 +883,101 @@ func (r *movieRepository) GetMoviesByMonth(ctx context.Context, year int) ([]mod
 
 	return results, nil
 }
+
+// CreateRevision stores a revision with the next revision number of its movie.
+// The movie row is locked so concurrent writers get consecutive numbers.
+func (r *movieRepository) CreateRevision(ctx context.Context, revision *models.MovieRevision) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.Transaction(ctx, func(tx *database.Database) error {
+		var movieID uint
+		if err := tx.Unscoped().Model(&models.Movie{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", revision.MovieID).
+			Pluck("id", &movieID).Error; err != nil {
+			return fmt.Errorf("failed to lock movie: %w", err)
+		}
+
+		var last int
+		if err := tx.Model(&models.MovieRevision{}).
+			Where("movie_id = ?", revision.MovieID).
+			Select("COALESCE(MAX(revision), 0)").
+			Scan(&last).Error; err != nil {
+			return fmt.Errorf("failed to read last revision: %w", err)
+		}
+
+		revision.Revision = last + 1
+		return tx.Create(revision).Error
+	})
+}
+
+func (r *movieRepository) FindRevisions(ctx context.Context, movieID uint, page, limit int) ([]models.MovieRevision, int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var revisions []models.MovieRevision
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.MovieRevision{}).Where("movie_id = ?", movieID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("revision DESC").Offset(offset).Limit(limit).Find(&revisions).Error
+	return revisions, total, err
+}
+
+func (r *movieRepository) FindRevision(ctx context.Context, movieID uint, revision int) (*models.MovieRevision, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var rev models.MovieRevision
+	err := r.db.WithContext(ctx).Where("movie_id = ? AND revision = ?", movieID, revision).First(&rev).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rev, nil
+}
+
+func (r *movieRepository) FindFieldLocks(ctx context.Context, movieID uint) ([]models.MovieFieldLock, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var locks []models.MovieFieldLock
+	err := r.db.WithContext(ctx).Where("movie_id = ?", movieID).Order("field ASC").Find(&locks).Error
+	return locks, err
+}
+
+// LockFields locks the given fields, keeping existing locks as they are
+func (r *movieRepository) LockFields(ctx context.Context, movieID uint, fields []string, lockedBy string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	locks := make([]models.MovieFieldLock, 0, len(fields))
+	for _, field := range fields {
+		locks = append(locks, models.MovieFieldLock{MovieID: movieID, Field: field, LockedBy: lockedBy})
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "movie_id"}, {Name: "field"}},
+		DoNothing: true,
+	}).Create(&locks).Error
+}
+
+func (r *movieRepository) UnlockField(ctx context.Context, movieID uint, field string) (bool, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Where("movie_id = ? AND field = ?", movieID, field).
+		Delete(&models.MovieFieldLock{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	"movie-backend/internal/database"
+	"movie-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// minUserRatingsForTopRated is how many approved ratings a movie needs to
+// appear among the dashboard's top rated by users
+const minUserRatingsForTopRated = 3
+
+type ReviewRepository interface {
+	// FindAll returns the reviews matching the filter, newest first, with
+	// the names of their authors
+	FindAll(ctx context.Context, filter models.ReviewFilter) ([]models.MovieReview, int64, error)
+	FindByID(ctx context.Context, id uint) (*models.MovieReview, error)
+	// FindByUserAndMovie returns the user's review of the movie, or nil
+	FindByUserAndMovie(ctx context.Context, userID, movieID uint) (*models.MovieReview, error)
+	// Upsert creates or replaces the user's review of the movie and updates
+	// the movie's user rating
+	Upsert(ctx context.Context, review *models.MovieReview) error
+	// Delete removes the user's review of the movie and updates the movie's
+	// user rating. It reports whether there was a review.
+	Delete(ctx context.Context, userID, movieID uint) (bool, error)
+	// Moderate saves the status, moderator and note of the review and
+	// updates the movie's user rating
+	Moderate(ctx context.Context, review *models.MovieReview) error
+}
+
+type reviewRepository struct {
+	db      *database.Database
+	timeout time.Duration
+}
+
+func NewReviewRepository(db *database.Database) ReviewRepository {
+	return &reviewRepository{
+		db:      db,
+		timeout: db.GetQueryTimeout(),
+	}
+}
+
+func (r *reviewRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, r.timeout)
+}
+
+// withAuthor selects reviews together with the names of their authors
+func withAuthor(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.MovieReview{}).
+		Select("movie_reviews.*, users.name AS author").
+		Joins("LEFT JOIN users ON users.id = movie_reviews.user_id")
+}
+
+// applyReviewFilter adds the conditions of the filter to a review query
+func applyReviewFilter(query *gorm.DB, filter models.ReviewFilter) *gorm.DB {
+	if filter.MovieID > 0 {
+		query = query.Where("movie_reviews.movie_id = ?", filter.MovieID)
+	}
+	if filter.UserID > 0 {
+		query = query.Where("movie_reviews.user_id = ?", filter.UserID)
+	}
+	if filter.Status != "" {
+		query = query.Where("movie_reviews.status = ?", filter.Status)
+	}
+	return query
+}
+
+func (r *reviewRepository) FindAll(ctx context.Context, filter models.ReviewFilter) ([]models.MovieReview, int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	db := r.db.WithContext(ctx)
+
+	var total int64
+	if err := applyReviewFilter(db.Model(&models.MovieReview{}), filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reviews []models.MovieReview
+	err := applyReviewFilter(withAuthor(db), filter).
+		Order("movie_reviews.created_at DESC, movie_reviews.id DESC").
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Find(&reviews).Error
+	return reviews, total, err
+}
+
+func (r *reviewRepository) FindByID(ctx context.Context, id uint) (*models.MovieReview, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var review models.MovieReview
+	err := withAuthor(r.db.WithContext(ctx)).Where("movie_reviews.id = ?", id).First(&review).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *reviewRepository) FindByUserAndMovie(ctx context.Context, userID, movieID uint) (*models.MovieReview, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var review models.MovieReview
+	err := withAuthor(r.db.WithContext(ctx)).
+		Where("movie_reviews.user_id = ? AND movie_reviews.movie_id = ?", userID, movieID).
+		First(&review).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *reviewRepository) Upsert(ctx context.Context, review *models.MovieReview) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.Transaction(ctx, func(tx *database.Database) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "movie_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"rating", "title", "body", "status", "moderated_by_id", "moderated_at", "moderation_note", "updated_at",
+			}),
+		}).Create(review).Error
+		if err != nil {
+			return err
+		}
+
+		var saved models.MovieReview
+		if err := withAuthor(tx.DB).
+			Where("movie_reviews.user_id = ? AND movie_reviews.movie_id = ?", review.UserID, review.MovieID).
+			First(&saved).Error; err != nil {
+			return err
+		}
+		*review = saved
+		return updateUserRating(tx, review.MovieID)
+	})
+}
+
+func (r *reviewRepository) Delete(ctx context.Context, userID, movieID uint) (bool, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	deleted := false
+	err := r.db.Transaction(ctx, func(tx *database.Database) error {
+		result := tx.Where("user_id = ? AND movie_id = ?", userID, movieID).Delete(&models.MovieReview{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		deleted = true
+		return updateUserRating(tx, movieID)
+	})
+	return deleted, err
+}
+
+func (r *reviewRepository) Moderate(ctx context.Context, review *models.MovieReview) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.Transaction(ctx, func(tx *database.Database) error {
+		if err := tx.Model(review).Select("status", "moderated_by_id", "moderated_at", "moderation_note", "updated_at").
+			Updates(review).Error; err != nil {
+			return err
+		}
+		return updateUserRating(tx, review.MovieID)
+	})
+}
+
+// updateUserRating recomputes the user rating average, count and histogram
+// of a movie from its approved reviews. The movie row is locked first so
+// concurrent reviews of the same movie are counted one after another. The
+// columns are written directly because movie updates never touch them, and
+// a new rating must not bump the movie's version.
+func updateUserRating(tx *database.Database, movieID uint) error {
+	if err := tx.Exec("SELECT id FROM movies WHERE id = ? FOR UPDATE", movieID).Error; err != nil {
+		return err
+	}
+
+	var counts []ratingCount
+	if err := tx.Model(&models.MovieReview{}).
+		Select("rating, COUNT(*) AS count").
+		Where("movie_id = ? AND status = ?", movieID, models.ReviewStatusApproved).
+		Group("rating").
+		Scan(&counts).Error; err != nil {
+		return err
+	}
+
+	average, total, histogram := summarizeRatings(counts)
+	data, err := json.Marshal(histogram)
+	if err != nil {
+		return err
+	}
+	return tx.Exec("UPDATE movies SET user_rating_average = ?, user_rating_count = ?, user_rating_histogram = ?, user_rating_updated_at = ? WHERE id = ?",
+		average, total, string(data), time.Now(), movieID).Error
+}
+
+// ratingCount is the number of approved reviews with one rating
+type ratingCount struct {
+	Rating int
+	Count  int
+}
+
+// summarizeRatings returns the average rounded to two decimals, the number of
+// ratings and the histogram, where histogram[i] counts ratings of i+1.
+// Ratings outside the valid range are ignored.
+func summarizeRatings(counts []ratingCount) (float64, int, []int) {
+	histogram := make([]int, models.MaxUserRating)
+	total, sum := 0, 0
+	for _, c := range counts {
+		if c.Rating < models.MinUserRating || c.Rating > models.MaxUserRating {
+			continue
+		}
+		histogram[c.Rating-1] = c.Count
+		total += c.Count
+		sum += c.Rating * c.Count
+	}
+	average := 0.0
+	if total > 0 {
+		average = math.Round(float64(sum)/float64(total)*100) / 100
+	}
+	return average, total, histogram
+}
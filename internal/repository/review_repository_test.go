@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSummarizeRatings(t *testing.T) {
+	tests := []struct {
+		name          string
+		counts        []ratingCount
+		wantAverage   float64
+		wantTotal     int
+		wantHistogram []int
+	}{
+		{
+			name:          "no approved reviews",
+			wantHistogram: []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:          "one rating",
+			counts:        []ratingCount{{Rating: 7, Count: 1}},
+			wantAverage:   7,
+			wantTotal:     1,
+			wantHistogram: []int{0, 0, 0, 0, 0, 0, 1, 0, 0, 0},
+		},
+		{
+			name:          "weighted by count",
+			counts:        []ratingCount{{Rating: 10, Count: 3}, {Rating: 1, Count: 1}},
+			wantAverage:   7.75,
+			wantTotal:     4,
+			wantHistogram: []int{1, 0, 0, 0, 0, 0, 0, 0, 0, 3},
+		},
+		{
+			name:          "rounded to two decimals",
+			counts:        []ratingCount{{Rating: 8, Count: 2}, {Rating: 9, Count: 1}},
+			wantAverage:   8.33,
+			wantTotal:     3,
+			wantHistogram: []int{0, 0, 0, 0, 0, 0, 0, 2, 1, 0},
+		},
+		{
+			name:          "out of range ratings are ignored",
+			counts:        []ratingCount{{Rating: 0, Count: 5}, {Rating: 5, Count: 2}, {Rating: 11, Count: 5}},
+			wantAverage:   5,
+			wantTotal:     2,
+			wantHistogram: []int{0, 0, 0, 0, 2, 0, 0, 0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			average, total, histogram := summarizeRatings(tt.counts)
+			if average != tt.wantAverage || total != tt.wantTotal {
+				t.Errorf("got average %v over %d ratings, want %v over %d", average, total, tt.wantAverage, tt.wantTotal)
+			}
+			if fmt.Sprint(histogram) != fmt.Sprint(tt.wantHistogram) {
+				t.Errorf("got histogram %v, want %v", histogram, tt.wantHistogram)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"movie-backend/internal/database"
+	"movie-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrTagNameTaken is returned when another tag has the same name, ignoring case
+var ErrTagNameTaken = errors.New("tag name is already taken")
+
+type TagRepository interface {
+	// Editor tags
+	Create(ctx context.Context, tag *models.Tag) error
+	FindByID(ctx context.Context, id uint) (*models.Tag, error)
+	FindByName(ctx context.Context, name string) (*models.Tag, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]models.Tag, error)
+	FindAllWithMovieCount(ctx context.Context) ([]models.TagWithCount, error)
+	CountMovies(ctx context.Context, id uint) (int64, error)
+	Update(ctx context.Context, tag *models.Tag) error
+	Delete(ctx context.Context, id uint) error
+	AddToMovie(ctx context.Context, movieID uint, tags []models.Tag) error
+	RemoveFromMovie(ctx context.Context, movieID, tagID uint) (bool, error)
+	FindByMovie(ctx context.Context, movieID uint) ([]models.Tag, error)
+
+	// TMDB keywords
+	ReplaceMovieKeywords(ctx context.Context, movieID uint, keywords []models.Keyword) error
+
+	// Cloud returns the most used tags or keywords with their live movie counts
+	Cloud(ctx context.Context, cloudType string, limit int) ([]models.TagCloudEntry, error)
+}
+
+type tagRepository struct {
+	db      *database.Database
+	timeout time.Duration
+}
+
+func NewTagRepository(db *database.Database) TagRepository {
+	return &tagRepository{
+		db:      db,
+		timeout: db.GetQueryTimeout(),
+	}
+}
+
+func (r *tagRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, r.timeout)
+}
+
+func (r *tagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return translateTagError(r.db.WithContext(ctx).Create(tag).Error)
+}
+
+func (r *tagRepository) FindByID(ctx context.Context, id uint) (*models.Tag, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var tag models.Tag
+	err := r.db.WithContext(ctx).First(&tag, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) FindByName(ctx context.Context, name string) (*models.Tag, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var tag models.Tag
+	err := r.db.WithContext(ctx).Where("LOWER(name) = LOWER(?)", name).First(&tag).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Tag, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var tags []models.Tag
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Order("name ASC").Find(&tags).Error
+	return tags, err
+}
+
+func (r *tagRepository) FindAllWithMovieCount(ctx context.Context) ([]models.TagWithCount, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var tags []models.TagWithCount
+	err := r.db.WithContext(ctx).
+		Table("tags").
+		Select("tags.*, COUNT(DISTINCT movies.id) AS movie_count").
+		Joins("LEFT JOIN movie_tags ON movie_tags.tag_id = tags.id").
+		Joins("LEFT JOIN movies ON movies.id = movie_tags.movie_id AND movies.deleted_at IS NULL").
+		Group("tags.id").
+		Order("tags.name ASC").
+		Scan(&tags).Error
+	return tags, err
+}
+
+func (r *tagRepository) CountMovies(ctx context.Context, id uint) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Movie{}).
+		Where("movies.id IN (SELECT movie_id FROM movie_tags WHERE tag_id = ?)", id).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *tagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return translateTagError(r.db.WithContext(ctx).Save(tag).Error)
+}
+
+// translateTagError maps a unique violation on the tag name, which a
+// concurrent create or rename can cause after the name was checked, to
+// ErrTagNameTaken
+func translateTagError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrTagNameTaken
+	}
+	return err
+}
+
+// Delete removes a tag from every movie and deletes it
+func (r *tagRepository) Delete(ctx context.Context, id uint) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.Transaction(ctx, func(tx *database.Database) error {
+		if err := tx.Exec("DELETE FROM movie_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Tag{}, id).Error
+	})
+}
+
+// AddToMovie tags a movie, skipping tags it already has
+func (r *tagRepository) AddToMovie(ctx context.Context, movieID uint, tags []models.Tag) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&models.Movie{ID: movieID}).Association("Tags").Append(tags)
+}
+
+func (r *tagRepository) RemoveFromMovie(ctx context.Context, movieID, tagID uint) (bool, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Exec("DELETE FROM movie_tags WHERE movie_id = ? AND tag_id = ?", movieID, tagID)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *tagRepository) FindByMovie(ctx context.Context, movieID uint) ([]models.Tag, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var tags []models.Tag
+	err := r.db.WithContext(ctx).
+		Where("id IN (SELECT tag_id FROM movie_tags WHERE movie_id = ?)", movieID).
+		Order("name ASC").
+		Find(&tags).Error
+	return tags, err
+}
+
+// ReplaceMovieKeywords stores the keywords, refreshing the names of known
+// ones, and makes them the keywords of the movie
+func (r *tagRepository) ReplaceMovieKeywords(ctx context.Context, movieID uint, keywords []models.Keyword) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.Transaction(ctx, func(tx *database.Database) error {
+		if len(keywords) > 0 {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "tmdb_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"name", "updated_at"}),
+			}).Create(&keywords).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.Movie{ID: movieID}).Association("Keywords").Replace(keywords)
+	})
+}
+
+func (r *tagRepository) Cloud(ctx context.Context, cloudType string, limit int) ([]models.TagCloudEntry, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	table, joinTable, column := "tags", "movie_tags", "tag_id"
+	if cloudType == models.TagCloudTypeKeyword {
+		table, joinTable, column = "keywords", "movie_keywords", "keyword_id"
+	}
+
+	var entries []models.TagCloudEntry
+	err := r.db.WithContext(ctx).
+		Table(table).
+		Select(table + ".id, " + table + ".name, COUNT(movies.id) AS movie_count").
+		Joins("JOIN " + joinTable + " ON " + joinTable + "." + column + " = " + table + ".id").
+		Joins("JOIN movies ON movies.id = " + joinTable + ".movie_id AND movies.deleted_at IS NULL").
+		Group(table + ".id").
+		Order("movie_count DESC, " + table + ".name ASC").
+		Limit(limit).
+		Scan(&entries).Error
+	for i := range entries {
+		entries[i].Type = cloudType
+	}
+	return entries, err
+}
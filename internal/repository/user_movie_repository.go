@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"movie-backend/internal/database"
+	"movie-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserMovieRepository interface {
+	// FindByMovies returns the user's saved state of the given movies; movies
+	// the user saved nothing about are left out
+	FindByMovies(ctx context.Context, userID uint, movieIDs []uint) ([]models.UserMovie, error)
+	// SetList puts a movie on one of the user's lists at the given time, or
+	// takes it off when at is nil, and returns the resulting state
+	SetList(ctx context.Context, userID, movieID uint, list string, at *time.Time) (*models.UserMovie, error)
+}
+
+// userListColumns are the flag and timestamp columns of each list. The
+// watched list has no flag; a watched_at date marks it.
+var userListColumns = map[string][]string{
+	models.UserListWatchlist: {"in_watchlist", "watchlisted_at"},
+	models.UserListFavorites: {"is_favorite", "favorited_at"},
+	models.UserListWatched:   {"watched_at"},
+}
+
+// userListCondition returns the SQL condition for movies on a user list
+func userListCondition(list string) string {
+	switch list {
+	case models.UserListWatchlist:
+		return "in_watchlist"
+	case models.UserListFavorites:
+		return "is_favorite"
+	case models.UserListWatched:
+		return "watched_at IS NOT NULL"
+	}
+	return "FALSE"
+}
+
+type userMovieRepository struct {
+	db      *database.Database
+	timeout time.Duration
+}
+
+func NewUserMovieRepository(db *database.Database) UserMovieRepository {
+	return &userMovieRepository{
+		db:      db,
+		timeout: db.GetQueryTimeout(),
+	}
+}
+
+func (r *userMovieRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, r.timeout)
+}
+
+func (r *userMovieRepository) FindByMovies(ctx context.Context, userID uint, movieIDs []uint) ([]models.UserMovie, error) {
+	if len(movieIDs) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var states []models.UserMovie
+	err := r.db.WithContext(ctx).Where("user_id = ? AND movie_id IN ?", userID, movieIDs).Find(&states).Error
+	return states, err
+}
+
+func (r *userMovieRepository) SetList(ctx context.Context, userID, movieID uint, list string, at *time.Time) (*models.UserMovie, error) {
+	columns, ok := userListColumns[list]
+	if !ok {
+		return nil, fmt.Errorf("unknown user list %q", list)
+	}
+
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	state := models.UserMovie{UserID: userID, MovieID: movieID}
+	switch list {
+	case models.UserListWatchlist:
+		state.InWatchlist, state.WatchlistedAt = at != nil, at
+	case models.UserListFavorites:
+		state.IsFavorite, state.FavoritedAt = at != nil, at
+	case models.UserListWatched:
+		state.WatchedAt = at
+	}
+
+	err := r.db.Transaction(ctx, func(tx *database.Database) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "movie_id"}},
+			DoUpdates: clause.AssignmentColumns(append(columns, "updated_at")),
+		}).Create(&state).Error
+		if err != nil {
+			return err
+		}
+
+		// Forget movies that are on none of the lists anymore
+		if err := tx.Where("user_id = ? AND movie_id = ? AND NOT in_watchlist AND NOT is_favorite AND watched_at IS NULL", userID, movieID).
+			Delete(&models.UserMovie{}).Error; err != nil {
+			return err
+		}
+
+		err = tx.Where("user_id = ? AND movie_id = ?", userID, movieID).First(&state).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			state = models.UserMovie{UserID: userID, MovieID: movieID}
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"movie-backend/internal/database"
+	"movie-backend/internal/models"
+
+	"gorm.io/gorm/clause"
+)
+
+type WatchProviderRepository interface {
+	ReplaceMovieProviders(ctx context.Context, movieID uint, availability []models.MovieWatchProvider) error
+	FindByMovie(ctx context.Context, movieID uint, region string) ([]models.MovieWatchProvider, error)
+
+	// FindAllWithCoverage returns providers by the number of live movies
+	// available with them, optionally in one region. A limit below 1 returns
+	// every provider.
+	FindAllWithCoverage(ctx context.Context, region string, limit int) ([]models.ProviderCoverage, error)
+}
+
+type watchProviderRepository struct {
+	db      *database.Database
+	timeout time.Duration
+}
+
+func NewWatchProviderRepository(db *database.Database) WatchProviderRepository {
+	return &watchProviderRepository{
+		db:      db,
+		timeout: db.GetQueryTimeout(),
+	}
+}
+
+func (r *watchProviderRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, r.timeout)
+}
+
+// ReplaceMovieProviders stores the providers of the availability entries,
+// refreshing the names and logos of known ones, and makes the entries the
+// availability of the movie
+func (r *watchProviderRepository) ReplaceMovieProviders(ctx context.Context, movieID uint, availability []models.MovieWatchProvider) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.db.Transaction(ctx, func(tx *database.Database) error {
+		if err := tx.Where("movie_id = ?", movieID).Delete(&models.MovieWatchProvider{}).Error; err != nil {
+			return err
+		}
+		if len(availability) == 0 {
+			return nil
+		}
+
+		providers := make([]models.WatchProvider, 0, len(availability))
+		seen := make(map[int]bool, len(availability))
+		for _, a := range availability {
+			if !seen[a.Provider.TMDBID] {
+				seen[a.Provider.TMDBID] = true
+				providers = append(providers, *a.Provider)
+			}
+		}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tmdb_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "logo_path", "display_priority", "updated_at"}),
+		}).Create(&providers).Error
+		if err != nil {
+			return err
+		}
+
+		ids := make(map[int]uint, len(providers))
+		for _, p := range providers {
+			ids[p.TMDBID] = p.ID
+		}
+		rows := make([]models.MovieWatchProvider, len(availability))
+		for i, a := range availability {
+			rows[i] = models.MovieWatchProvider{
+				MovieID:    movieID,
+				ProviderID: ids[a.Provider.TMDBID],
+				Region:     a.Region,
+				Type:       a.Type,
+				Link:       a.Link,
+			}
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+// FindByMovie returns the availability of a movie with its providers, by
+// region and provider display priority
+func (r *watchProviderRepository) FindByMovie(ctx context.Context, movieID uint, region string) ([]models.MovieWatchProvider, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := r.db.WithContext(ctx).
+		Preload("Provider").
+		Joins("JOIN watch_providers ON watch_providers.id = movie_watch_providers.provider_id").
+		Where("movie_watch_providers.movie_id = ?", movieID)
+	if region != "" {
+		query = query.Where("movie_watch_providers.region = ?", region)
+	}
+
+	var availability []models.MovieWatchProvider
+	err := query.
+		Order("movie_watch_providers.region ASC, watch_providers.display_priority ASC, watch_providers.name ASC").
+		Find(&availability).Error
+	return availability, err
+}
+
+func (r *watchProviderRepository) FindAllWithCoverage(ctx context.Context, region string, limit int) ([]models.ProviderCoverage, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	availability := "JOIN movie_watch_providers ON movie_watch_providers.provider_id = watch_providers.id"
+	var args []interface{}
+	if region != "" {
+		availability += " AND movie_watch_providers.region = ?"
+		args = append(args, region)
+	}
+
+	query := r.db.WithContext(ctx).
+		Table("watch_providers").
+		Select("watch_providers.id AS provider_id, watch_providers.name, watch_providers.logo_path, "+
+			"COUNT(DISTINCT movies.id) AS movie_count, "+
+			"COALESCE(ROUND(COUNT(DISTINCT movies.id) * 100.0 / "+
+			"NULLIF((SELECT COUNT(*) FROM movies WHERE deleted_at IS NULL), 0), 2), 0) AS coverage").
+		Joins(availability, args...).
+		Joins("JOIN movies ON movies.id = movie_watch_providers.movie_id AND movies.deleted_at IS NULL").
+		Group("watch_providers.id").
+		Order("movie_count DESC, watch_providers.name ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var coverage []models.ProviderCoverage
+	err := query.Scan(&coverage).Error
+	return coverage, err
+}
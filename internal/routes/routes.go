@@ -2,23 +2,139 @@ package routes
 
 import (
 	"movie-backend/internal/handlers"
+	"movie-backend/internal/middleware"
+	"movie-backend/internal/models"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func Setup(app *fiber.App, movieHandler *handlers.MovieHandler, uploadHandler *handlers.UploadHandler) {
+// accessPolicies are the minimum roles per route group, below /api/v1, and
+// the scopes API keys need instead. Empty roles are open to anonymous callers.
+// Viewers can read, keep their own lists and review movies; editors manage
+// the catalog, see its trash, history and sync log, moderate reviews and
+// upload images; admins also sync, purge and manage users and API keys.
+var accessPolicies = map[string]middleware.AccessPolicy{
+	"/auth":               {},
+	"/movies":             {Write: models.RoleEditor, ReadScope: models.ScopeMoviesRead, WriteScope: models.ScopeMoviesWrite},
+	"/movies/trash":       {Read: models.RoleEditor, Write: models.RoleEditor, WriteScope: models.ScopeMoviesWrite},
+	"/movies/trash/purge": {Write: models.RoleAdmin},
+	"/movies/:id/history": {Read: models.RoleEditor, Write: models.RoleEditor, WriteScope: models.ScopeMoviesWrite},
+	"/genres":             {Write: models.RoleEditor, ReadScope: models.ScopeMoviesRead, WriteScope: models.ScopeMoviesWrite},
+	"/tags":               {Write: models.RoleEditor, ReadScope: models.ScopeMoviesRead, WriteScope: models.ScopeMoviesWrite},
+	"/collections":        {Write: models.RoleEditor, ReadScope: models.ScopeMoviesRead, WriteScope: models.ScopeMoviesWrite},
+	"/providers":          {Write: models.RoleEditor, ReadScope: models.ScopeMoviesRead, WriteScope: models.ScopeMoviesWrite},
+	"/languages":          {Write: models.RoleEditor, ReadScope: models.ScopeMoviesRead, WriteScope: models.ScopeMoviesWrite},
+	"/sync":               {Write: models.RoleAdmin, ReadScope: models.ScopeMoviesRead, WriteScope: models.ScopeSyncRun},
+	"/sync/last-log":      {Read: models.RoleEditor, Write: models.RoleAdmin, ReadScope: models.ScopeSyncRun, WriteScope: models.ScopeSyncRun},
+	"/dashboard":          {ReadScope: models.ScopeMoviesRead},
+	"/charts":             {ReadScope: models.ScopeMoviesRead},
+	"/upload":             {Read: models.RoleEditor, Write: models.RoleEditor, ReadScope: models.ScopeUploadWrite, WriteScope: models.ScopeUploadWrite},
+	"/users":              {Read: models.RoleAdmin, Write: models.RoleAdmin},
+	"/api-keys":           {Read: models.RoleAdmin, Write: models.RoleAdmin},
+	"/me":                 {Read: models.RoleViewer, Write: models.RoleViewer},
+	"/reviews":            {Read: models.RoleEditor, Write: models.RoleEditor},
+}
+
+func Setup(app *fiber.App, movieHandler *handlers.MovieHandler, uploadHandler *handlers.UploadHandler, authHandler *handlers.AuthHandler, authFailureLimit, authenticate, rateLimit, idempotency fiber.Handler) {
 	// API versioning
 	api := app.Group("/api")
 	v1 := api.Group("/v1")
 
+	// Count invalid access tokens and API keys per IP, since authenticate
+	// rejects them before the caller is known
+	v1.Use(authFailureLimit)
+	// Resolve the caller from the access token or API key, if any, and check
+	// their role or scopes.
+	// This runs before idempotency so rejected attempts are never stored.
+	v1.Use(authenticate)
+	// Count every authenticated or anonymous request against the caller,
+	// including ones Authorize rejects
+	v1.Use(rateLimit)
+	v1.Use(middleware.Authorize("/api/v1", accessPolicies))
+
+	// Auth routes - public, so they are registered before idempotency
+	auth := v1.Group("/auth")
+	{
+		auth.Post("/register", authHandler.Register)
+		auth.Post("/login", authHandler.Login)
+		auth.Post("/refresh", authHandler.RefreshToken)
+		auth.Post("/logout", authHandler.Logout)
+		auth.Get("/me", middleware.RequireAuth(), authHandler.Me)
+	}
+
+	// Replay stored responses for retried mutating requests
+	v1.Use(idempotency)
+
 	// Movie routes - CRUD operations
 	movies := v1.Group("/movies")
 	{
 		movies.Get("/", movieHandler.GetAllMovies)
+		movies.Get("/export", movieHandler.ExportMovies)
+		movies.Get("/trash", movieHandler.GetTrashedMovies)
 		movies.Get("/:id", movieHandler.GetMovieByID)
+		movies.Get("/:id/history", movieHandler.GetMovieHistory)
+		movies.Get("/:id/locks", movieHandler.GetMovieLocks)
+		movies.Get("/:id/videos", movieHandler.GetMovieVideos)
+		movies.Get("/:id/release-dates", movieHandler.GetMovieReleaseDates)
+		movies.Get("/:id/providers", movieHandler.GetMovieProviders)
+		movies.Get("/:id/reviews", movieHandler.GetMovieReviews)
 		movies.Post("/", movieHandler.CreateMovie)
+		movies.Post("/import", movieHandler.ImportMovies)
+		movies.Post("/trash/purge", movieHandler.PurgeTrash)
+		movies.Post("/:id/restore", movieHandler.RestoreMovie)
+		movies.Post("/:id/revert/:revision", movieHandler.RevertMovie)
+		movies.Post("/:id/locks", movieHandler.LockMovieFields)
+		movies.Post("/:id/tags", movieHandler.TagMovie)
 		movies.Put("/:id", movieHandler.UpdateMovie)
+		movies.Patch("/:id", movieHandler.PatchMovie)
 		movies.Delete("/:id", movieHandler.DeleteMovie)
+		movies.Delete("/:id/locks/:field", movieHandler.UnlockMovieField)
+		movies.Delete("/:id/tags/:tagId", movieHandler.UntagMovie)
+	}
+
+	// Genre routes - listing and management
+	genres := v1.Group("/genres")
+	{
+		genres.Get("/", movieHandler.GetGenres)
+		genres.Get("/:id/movies", movieHandler.GetGenreMovies)
+		genres.Post("/", movieHandler.CreateGenre)
+		genres.Put("/:id", movieHandler.RenameGenre)
+		genres.Post("/:id/merge", movieHandler.MergeGenres)
+	}
+
+	// Tag routes - editor tags and the tag cloud
+	tags := v1.Group("/tags")
+	{
+		tags.Get("/", movieHandler.GetTags)
+		tags.Get("/cloud", movieHandler.GetTagCloud)
+		tags.Get("/:id", movieHandler.GetTag)
+		tags.Post("/", movieHandler.CreateTag)
+		tags.Put("/:id", movieHandler.UpdateTag)
+		tags.Delete("/:id", movieHandler.DeleteTag)
+	}
+
+	// Collection routes - franchises synced from TMDB
+	collections := v1.Group("/collections")
+	{
+		collections.Get("/", movieHandler.GetCollections)
+		collections.Get("/:id", movieHandler.GetCollection)
+		collections.Get("/:id/movies", movieHandler.GetCollectionMovies)
+	}
+
+	// Watch provider routes - streaming availability synced from TMDB
+	providers := v1.Group("/providers")
+	{
+		providers.Get("/", movieHandler.GetProviders)
+	}
+
+	// Language routes - listing, names and translations
+	languages := v1.Group("/languages")
+	{
+		languages.Get("/", movieHandler.GetLanguages)
+		languages.Get("/:id", movieHandler.GetLanguage)
+		languages.Put("/:id", movieHandler.UpdateLanguage)
+		languages.Put("/:id/translations/:locale", movieHandler.SetLanguageTranslation)
+		languages.Delete("/:id/translations/:locale", movieHandler.DeleteLanguageTranslation)
 	}
 
 	// Sync routes - TMDB synchronization
@@ -45,6 +161,42 @@ func Setup(app *fiber.App, movieHandler *handlers.MovieHandler, uploadHandler *h
 
 	upload := v1.Group("/upload")
 	{
-		upload.Get("/presign", uploadHandler.GetPresignedURL)
+		upload.Get("/presign", middleware.RequireAuth(), uploadHandler.GetPresignedURL)
+	}
+
+	// Personal routes - the caller's reviews, watchlist, favorites and watched
+	// movies. Reviews are registered first so /me/:list doesn't match them.
+	me := v1.Group("/me")
+	{
+		me.Get("/reviews", movieHandler.GetUserReviews)
+		me.Get("/reviews/:movieId", movieHandler.GetUserReview)
+		me.Put("/reviews/:movieId", movieHandler.ReviewMovie)
+		me.Delete("/reviews/:movieId", movieHandler.DeleteUserReview)
+		me.Get("/:list", movieHandler.GetUserMovies)
+		me.Put("/:list/:movieId", movieHandler.AddUserMovie)
+		me.Delete("/:list/:movieId", movieHandler.RemoveUserMovie)
+	}
+
+	// Review routes - moderation of user reviews
+	reviews := v1.Group("/reviews")
+	{
+		reviews.Get("/", movieHandler.GetReviews)
+		reviews.Put("/:id/moderation", movieHandler.ModerateReview)
+	}
+
+	// User routes - account and role management
+	users := v1.Group("/users")
+	{
+		users.Get("/", authHandler.GetUsers)
+		users.Put("/:id/role", authHandler.SetUserRole)
+		users.Delete("/:id", authHandler.DeleteUser)
+	}
+
+	// API key routes - keys for service-to-service clients
+	apiKeys := v1.Group("/api-keys")
+	{
+		apiKeys.Get("/", authHandler.GetAPIKeys)
+		apiKeys.Post("/", authHandler.CreateAPIKey)
+		apiKeys.Delete("/:id", authHandler.DeleteAPIKey)
 	}
 }
@@ -0,0 +1,126 @@
+package routes
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"movie-backend/internal/middleware"
+	"movie-backend/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// newPolicyApp checks the access policies in front of a handler that always
+// succeeds. The X-Test-Caller header stands in for Authenticate: a role, or
+// "key:" followed by comma separated scopes.
+func newPolicyApp() *fiber.App {
+	app := fiber.New()
+	v1 := app.Group("/api/v1")
+	v1.Use(func(c *fiber.Ctx) error {
+		caller := c.Get("X-Test-Caller")
+		switch {
+		case caller == "":
+		case strings.HasPrefix(caller, "key:"):
+			scopes := strings.Split(strings.TrimPrefix(caller, "key:"), ",")
+			c.Locals(middleware.LocalsIdentityKey, &models.Identity{APIKey: &models.APIKey{ID: 1, Name: "test", Scopes: scopes}})
+		default:
+			c.Locals(middleware.LocalsIdentityKey, &models.Identity{UserID: 1, Email: "user@example.com", Role: caller})
+		}
+		return c.Next()
+	})
+	v1.Use(middleware.Authorize("/api/v1", accessPolicies))
+	v1.All("/*", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func TestAccessPolicies(t *testing.T) {
+	const (
+		ok           = fiber.StatusOK
+		unauthorized = fiber.StatusUnauthorized
+		forbidden    = fiber.StatusForbidden
+	)
+	callers := []string{"", models.RoleViewer, models.RoleEditor, models.RoleAdmin, "key:" + models.ScopeMoviesRead, "key:" + strings.Join(models.APIKeyScopes, ",")}
+
+	tests := []struct {
+		method string
+		path   string
+		want   []int // per caller: anonymous, viewer, editor, admin, read key, key with every scope
+	}{
+		{fiber.MethodGet, "/movies", []int{ok, ok, ok, ok, ok, ok}},
+		{fiber.MethodGet, "/movies/1/reviews", []int{ok, ok, ok, ok, ok, ok}},
+		{fiber.MethodPost, "/movies", []int{unauthorized, forbidden, ok, ok, forbidden, ok}},
+		{fiber.MethodPut, "/Movies/1", []int{unauthorized, forbidden, ok, ok, forbidden, ok}},
+		{fiber.MethodGet, "/movies/1", []int{ok, ok, ok, ok, ok, ok}},
+		{fiber.MethodGet, "/movies/trash", []int{unauthorized, forbidden, ok, ok, forbidden, forbidden}},
+		{fiber.MethodGet, "/Movies/Trash/", []int{unauthorized, forbidden, ok, ok, forbidden, forbidden}},
+		{fiber.MethodPost, "/movies/1/restore", []int{unauthorized, forbidden, ok, ok, forbidden, ok}},
+		{fiber.MethodGet, "/movies/1/history", []int{unauthorized, forbidden, ok, ok, forbidden, forbidden}},
+		{fiber.MethodGet, "/MOVIES/1/HISTORY", []int{unauthorized, forbidden, ok, ok, forbidden, forbidden}},
+		{fiber.MethodPost, "/movies/trash/purge", []int{unauthorized, forbidden, forbidden, ok, forbidden, forbidden}},
+		{fiber.MethodPost, "/MOVIES/Trash/Purge/", []int{unauthorized, forbidden, forbidden, ok, forbidden, forbidden}},
+		{fiber.MethodPost, "/genres/1/merge", []int{unauthorized, forbidden, ok, ok, forbidden, ok}},
+		{fiber.MethodDelete, "/tags/1", []int{unauthorized, forbidden, ok, ok, forbidden, ok}},
+		{fiber.MethodGet, "/collections", []int{ok, ok, ok, ok, ok, ok}},
+		{fiber.MethodPut, "/languages/1", []int{unauthorized, forbidden, ok, ok, forbidden, ok}},
+		{fiber.MethodGet, "/sync/last-log", []int{unauthorized, forbidden, ok, ok, forbidden, ok}},
+		{fiber.MethodGet, "/Sync/Last-Log/", []int{unauthorized, forbidden, ok, ok, forbidden, ok}},
+		{fiber.MethodPost, "/sync/movies", []int{unauthorized, forbidden, forbidden, ok, forbidden, ok}},
+		{fiber.MethodGet, "/charts/pie", []int{ok, ok, ok, ok, ok, ok}},
+		{fiber.MethodGet, "/dashboard/stats", []int{ok, ok, ok, ok, ok, ok}},
+		{fiber.MethodGet, "/upload/presign", []int{unauthorized, forbidden, ok, ok, forbidden, ok}},
+		{fiber.MethodGet, "/me/watchlist", []int{unauthorized, ok, ok, ok, forbidden, forbidden}},
+		{fiber.MethodPut, "/me/reviews/1", []int{unauthorized, ok, ok, ok, forbidden, forbidden}},
+		{fiber.MethodGet, "/reviews", []int{unauthorized, forbidden, ok, ok, forbidden, forbidden}},
+		{fiber.MethodPut, "/reviews/1/moderation", []int{unauthorized, forbidden, ok, ok, forbidden, forbidden}},
+		{fiber.MethodGet, "/users", []int{unauthorized, forbidden, forbidden, ok, forbidden, forbidden}},
+		{fiber.MethodPut, "/Users/1/role", []int{unauthorized, forbidden, forbidden, ok, forbidden, forbidden}},
+		{fiber.MethodPost, "/api-keys", []int{unauthorized, forbidden, forbidden, ok, forbidden, forbidden}},
+		{fiber.MethodPost, "/auth/login", []int{ok, ok, ok, ok, ok, ok}},
+		{fiber.MethodGet, "/unknown", []int{unauthorized, forbidden, forbidden, ok, forbidden, forbidden}},
+	}
+
+	app := newPolicyApp()
+	for _, tt := range tests {
+		for i, caller := range callers {
+			req := httptest.NewRequest(tt.method, "/api/v1"+tt.path, nil)
+			if caller != "" {
+				req.Header.Set("X-Test-Caller", caller)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("%s %s as %q: request failed: %v", tt.method, tt.path, caller, err)
+			}
+			if resp.StatusCode != tt.want[i] {
+				t.Errorf("%s %s as %q: got status %d, want %d", tt.method, tt.path, caller, resp.StatusCode, tt.want[i])
+			}
+		}
+	}
+}
+
+// TestEveryRouteHasAPolicy keeps new route groups from silently falling back
+// to the admin-only default
+func TestEveryRouteHasAPolicy(t *testing.T) {
+	app := fiber.New()
+	next := func(c *fiber.Ctx) error { return c.Next() }
+	Setup(app, nil, nil, nil, next, next, next, next)
+
+	for _, route := range app.GetRoutes(true) {
+		path, ok := strings.CutPrefix(route.Path, "/api/v1")
+		if !ok || route.Method == fiber.MethodHead {
+			continue
+		}
+		found := false
+		for prefix := range accessPolicies {
+			if path == prefix || strings.HasPrefix(path, prefix+"/") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("%s %s has no access policy", route.Method, route.Path)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// analyticsCachePrefix namespaces dashboard and chart results in the cache
+const analyticsCachePrefix = "analytics:"
+
+// ErrInvalidChartDate is returned for a chart start or end date that isn't
+// formatted as YYYY-MM-DD
+var ErrInvalidChartDate = errors.New("start_date and end_date must be formatted as YYYY-MM-DD")
+
+// chartCacheKey builds the cache key of a chart filtered by dates and region.
+// Each chart has its own prefix and the dates are validated first, so no
+// two charts or filters can share a key.
+func chartCacheKey(chart, startDate, endDate, region string) (string, error) {
+	for _, date := range []string{startDate, endDate} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return "", ErrInvalidChartDate
+		}
+	}
+	return fmt.Sprintf("charts:%s:%s:%s:%s", chart, startDate, endDate, region), nil
+}
+
+// cachedAnalytics returns the cached result for key, or runs load and caches
+// its result. Concurrent misses for the same key share a single load. Results
+// loaded while the cache was being invalidated are returned but not stored.
+func cachedAnalytics[T any](ctx context.Context, s *movieService, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	key = analyticsCachePrefix + key
+
+	data, ok, err := s.cache.Get(ctx, key)
+	if err != nil {
+		s.logger.WithError(err).WithField("key", key).Warn("Failed to read from cache")
+	}
+	if ok {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+		s.logger.WithField("key", key).Warn("Discarding undecodable cache entry")
+	}
+
+	generation := s.cacheGeneration.Load()
+	result, err, _ := s.cacheGroup.Do(fmt.Sprintf("%s#%d", key, generation), func() (interface{}, error) {
+		// The load is shared, so it must not be cancelled with the first caller
+		loadCtx := context.WithoutCancel(ctx)
+
+		value, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		if s.cacheGeneration.Load() == generation {
+			if data, err := json.Marshal(value); err != nil {
+				s.logger.WithError(err).WithField("key", key).Warn("Failed to encode cache entry")
+			} else if err := s.cache.Set(loadCtx, key, data, s.config.Cache.TTL); err != nil {
+				s.logger.WithError(err).WithField("key", key).Warn("Failed to write to cache")
+			}
+		}
+		return value, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return result.(T), nil
+}
+
+// invalidateAnalyticsCache drops every cached dashboard and chart result
+func (s *movieService) invalidateAnalyticsCache(ctx context.Context) {
+	s.cacheGeneration.Add(1)
+	if err := s.cache.DeletePrefix(ctx, analyticsCachePrefix); err != nil {
+		s.logger.WithError(err).WithField("prefix", analyticsCachePrefix).Warn("Failed to invalidate analytics cache")
+	}
+}
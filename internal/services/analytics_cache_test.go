@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"movie-backend/internal/config"
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+)
+
+// chartMovieRepository counts the chart queries that reach the database
+type chartMovieRepository struct {
+	repository.MovieRepository
+	yearQueries int
+}
+
+func (r *chartMovieRepository) GetMoviesByLanguage(context.Context) ([]models.PieChartData, error) {
+	return []models.PieChartData{{Label: "English", Value: 1}}, nil
+}
+
+func (r *chartMovieRepository) GetMoviesByYear(_ context.Context, startDate, _, _ string) ([]models.ColumnChartData, error) {
+	r.yearQueries++
+	return []models.ColumnChartData{{Label: startDate, Value: int64(r.yearQueries)}}, nil
+}
+
+func TestChartCacheKeys(t *testing.T) {
+	repo := &chartMovieRepository{}
+	service := &movieService{
+		repo:   repo,
+		config: &config.Config{},
+		logger: discardLogger(),
+		cache:  NewMemoryCache(10),
+	}
+	ctx := context.Background()
+
+	if _, err := service.GetMoviesByYear(ctx, "2020-01-01", "2020-12-31", "US"); err != nil {
+		t.Fatalf("GetMoviesByYear: %v", err)
+	}
+	if _, err := service.GetMoviesByYear(ctx, "2020-01-01", "2020-12-31", "US"); err != nil {
+		t.Fatalf("GetMoviesByYear: %v", err)
+	}
+	if repo.yearQueries != 1 {
+		t.Errorf("got %d year queries for the same filter, want 1", repo.yearQueries)
+	}
+
+	// The combined chart with the same filter is cached on its own
+	charts, err := service.GetChartData(ctx, "2020-01-01", "2020-12-31", "US")
+	if err != nil {
+		t.Fatalf("GetChartData: %v", err)
+	}
+	if repo.yearQueries != 2 || len(charts.PieChart) != 1 {
+		t.Errorf("got %d year queries and pie chart %+v, want the combined chart loaded", repo.yearQueries, charts.PieChart)
+	}
+
+	tests := []struct {
+		name      string
+		startDate string
+		endDate   string
+	}{
+		{"chart name as start date", "column", "2020-12-31"},
+		{"key separator in end date", "2020-01-01", "2020-12-31:US"},
+		{"not a date", "2020-13-01", ""},
+		{"not zero padded", "2020-1-1", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.GetChartData(ctx, tt.startDate, tt.endDate, ""); !errors.Is(err, ErrInvalidChartDate) {
+				t.Errorf("GetChartData returned %v, want %v", err, ErrInvalidChartDate)
+			}
+			if _, err := service.GetMoviesByYear(ctx, tt.startDate, tt.endDate, ""); !errors.Is(err, ErrInvalidChartDate) {
+				t.Errorf("GetMoviesByYear returned %v, want %v", err, ErrInvalidChartDate)
+			}
+		})
+	}
+}
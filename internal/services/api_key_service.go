@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"movie-backend/internal/models"
+)
+
+var (
+	ErrInvalidAPIKey      = errors.New("API key is invalid or expired")
+	ErrAPIKeyNotFound     = errors.New("API key not found")
+	ErrAPIKeyNameRequired = errors.New("API key name is required")
+	ErrAPIKeyScopes       = errors.New("scopes must be one or more of movies:read, movies:write, sync:run and upload:write")
+	ErrAPIKeyExpiry       = errors.New("expires_at must be in the future")
+)
+
+const (
+	apiKeyPrefix = "mk_"
+	apiKeyBytes  = 32
+	// Uses of a key are recorded at most this often
+	apiKeyLastUsedInterval = time.Minute
+)
+
+func (s *authService) CreateAPIKey(ctx context.Context, actorID uint, name string, scopes []string, expiresAt *time.Time) (*models.CreatedAPIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrAPIKeyNameRequired
+	}
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, ErrAPIKeyExpiry
+	}
+
+	raw := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	apiKey := &models.APIKey{
+		Name:        name,
+		Prefix:      key[:len(apiKeyPrefix)+6],
+		KeyHash:     hashToken(key),
+		Scopes:      scopes,
+		ExpiresAt:   expiresAt,
+		CreatedByID: actorID,
+	}
+	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, err
+	}
+	return &models.CreatedAPIKey{Key: key, APIKey: apiKey}, nil
+}
+
+func (s *authService) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	return s.apiKeyRepo.FindAll(ctx)
+}
+
+// DeleteAPIKey revokes a key; requests sending it are rejected right away
+func (s *authService) DeleteAPIKey(ctx context.Context, id uint) error {
+	key, err := s.apiKeyRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return ErrAPIKeyNotFound
+	}
+	return s.apiKeyRepo.Delete(ctx, id)
+}
+
+func (s *authService) VerifyAPIKey(ctx context.Context, key string) (*models.Identity, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+	apiKey, err := s.apiKeyRepo.FindByHash(ctx, hashToken(key))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if apiKey == nil || (apiKey.ExpiresAt != nil && now.After(*apiKey.ExpiresAt)) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if err := s.apiKeyRepo.TouchLastUsed(ctx, apiKey.ID, now, now.Add(-apiKeyLastUsedInterval)); err != nil {
+		s.logger.WithError(err).WithField("api_key_id", apiKey.ID).Warn("Failed to record API key use")
+	}
+	return &models.Identity{APIKey: apiKey}, nil
+}
+
+// normalizeScopes validates scopes and removes duplicates
+func normalizeScopes(scopes []string) ([]string, error) {
+	normalized := make([]string, 0, len(scopes))
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !models.ValidScope(scope) {
+			return nil, ErrAPIKeyScopes
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, ErrAPIKeyScopes
+	}
+	return normalized, nil
+}
@@ -0,0 +1,382 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"movie-backend/internal/config"
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrInvalidEmail        = errors.New("a valid email is required")
+	ErrPasswordTooShort    = errors.New("password must be at least 8 characters")
+	ErrPasswordTooLong     = errors.New("password must be at most 72 bytes")
+	ErrEmailTaken          = errors.New("an account with this email already exists")
+	ErrInvalidCredentials  = errors.New("invalid email or password")
+	ErrInvalidRefreshToken = errors.New("refresh token is invalid or expired")
+	ErrInvalidAccessToken  = errors.New("access token is invalid or expired")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrInvalidRole         = errors.New("role must be viewer, editor or admin")
+	ErrCannotChangeSelf    = errors.New("admins cannot change their own role or delete their own account")
+)
+
+const (
+	minPasswordLength = 8
+	// bcrypt ignores everything after 72 bytes
+	maxPasswordLength = 72
+	refreshTokenBytes = 32
+)
+
+type AuthService interface {
+	// Register creates a viewer account
+	Register(ctx context.Context, email, password, name string) (*models.User, error)
+	// SeedAdmin creates the ADMIN_EMAIL account as an admin, unless the
+	// email is already registered. Existing accounts are never promoted.
+	SeedAdmin(ctx context.Context) error
+	Login(ctx context.Context, email, password string) (*models.AuthTokens, error)
+	// Refresh exchanges a refresh token for new tokens. The presented token is
+	// used up; presenting it again revokes every token of its login.
+	Refresh(ctx context.Context, refreshToken string) (*models.AuthTokens, error)
+	Logout(ctx context.Context, refreshToken string) error
+	VerifyAccessToken(token string) (*models.Identity, error)
+	GetUser(ctx context.Context, id uint) (*models.User, error)
+	DeleteExpiredRefreshTokens(ctx context.Context) (int64, error)
+
+	// User management, for admins
+	GetUsers(ctx context.Context, page, limit int) ([]models.User, int64, error)
+	SetUserRole(ctx context.Context, actorID, id uint, role string) (*models.User, error)
+	DeleteUser(ctx context.Context, actorID, id uint) error
+
+	// API keys, for clients that can't log in interactively. Keys are
+	// managed by admins.
+	VerifyAPIKey(ctx context.Context, key string) (*models.Identity, error)
+	CreateAPIKey(ctx context.Context, actorID uint, name string, scopes []string, expiresAt *time.Time) (*models.CreatedAPIKey, error)
+	GetAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	DeleteAPIKey(ctx context.Context, id uint) error
+}
+
+type authService struct {
+	repo       repository.UserRepository
+	apiKeyRepo repository.APIKeyRepository
+	config     *config.AuthConfig
+	logger     *logrus.Logger
+}
+
+// accessClaims are the claims of an access token. The subject is the user ID.
+type accessClaims struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// NewAuthService returns an error when cfg has no usable JWT secret
+func NewAuthService(repo repository.UserRepository, apiKeyRepo repository.APIKeyRepository, cfg *config.AuthConfig, logger *logrus.Logger) (AuthService, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &authService{
+		repo:       repo,
+		apiKeyRepo: apiKeyRepo,
+		config:     cfg,
+		logger:     logger,
+	}, nil
+}
+
+func (s *authService) Register(ctx context.Context, email, password, name string) (*models.User, error) {
+	return s.createUser(ctx, email, password, name, models.RoleViewer)
+}
+
+// createUser validates the email and password and creates an account with role
+func (s *authService) createUser(ctx context.Context, email, password, name, role string) (*models.User, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if len(password) < minPasswordLength {
+		return nil, ErrPasswordTooShort
+	}
+	if len(password) > maxPasswordLength {
+		return nil, ErrPasswordTooLong
+	}
+
+	existing, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing user: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrEmailTaken
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := &models.User{
+		Email:        email,
+		Name:         strings.TrimSpace(name),
+		Role:         role,
+		PasswordHash: string(hash),
+	}
+	if err := s.repo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *authService) SeedAdmin(ctx context.Context) error {
+	if s.config.AdminEmail == "" || s.config.AdminPassword == "" {
+		return nil
+	}
+	email, err := normalizeEmail(s.config.AdminEmail)
+	if err != nil {
+		return fmt.Errorf("ADMIN_EMAIL: %w", err)
+	}
+
+	existing, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to check existing user: %w", err)
+	}
+	if existing != nil {
+		// Anyone can register an address, so an existing account is not
+		// proof of owning it; an admin has to promote it
+		if existing.Role != models.RoleAdmin {
+			s.logger.WithField("email", email).Warn("ADMIN_EMAIL belongs to an existing account that is not an admin; it was not promoted")
+		}
+		return nil
+	}
+
+	if _, err := s.createUser(ctx, email, s.config.AdminPassword, "Admin", models.RoleAdmin); err != nil {
+		return fmt.Errorf("failed to create admin: %w", err)
+	}
+	s.logger.WithField("email", email).Info("Created admin account from ADMIN_EMAIL")
+	return nil
+}
+
+func (s *authService) Login(ctx context.Context, email, password string) (*models.AuthTokens, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	user, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		// Spend the time of a password check so response times don't reveal
+		// which emails have accounts
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	now := time.Now().UTC()
+	if err := s.repo.TouchLastLogin(ctx, user.ID, now); err != nil {
+		s.logger.WithError(err).WithField("user_id", user.ID).Warn("Failed to record last login")
+	}
+	user.LastLoginAt = &now
+
+	return s.issueTokens(ctx, user, uuid.NewString())
+}
+
+func (s *authService) Refresh(ctx context.Context, refreshToken string) (*models.AuthTokens, error) {
+	stored, err := s.repo.FindRefreshToken(ctx, hashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil || time.Now().After(stored.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	rotated, err := s.repo.RevokeRefreshToken(ctx, stored.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// The token was already used, so it leaked: end the whole login
+		if err := s.repo.RevokeRefreshTokenFamily(ctx, stored.FamilyID); err != nil {
+			return nil, err
+		}
+		s.logger.WithFields(logrus.Fields{"user_id": stored.UserID, "family_id": stored.FamilyID}).
+			Warn("Refresh token reused, revoked its token family")
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.repo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	return s.issueTokens(ctx, user, stored.FamilyID)
+}
+
+// Logout revokes every refresh token of the login the token belongs to.
+// Unknown tokens are ignored.
+func (s *authService) Logout(ctx context.Context, refreshToken string) error {
+	stored, err := s.repo.FindRefreshToken(ctx, hashToken(refreshToken))
+	if err != nil || stored == nil {
+		return err
+	}
+	return s.repo.RevokeRefreshTokenFamily(ctx, stored.FamilyID)
+}
+
+func (s *authService) VerifyAccessToken(token string) (*models.Identity, error) {
+	var claims accessClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return s.signingKey()
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(s.config.JWTIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, ErrInvalidAccessToken
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidAccessToken
+	}
+	// Tokens without a known role only get read access
+	role := claims.Role
+	if !models.ValidRole(role) {
+		role = models.RoleViewer
+	}
+	return &models.Identity{UserID: uint(userID), Email: claims.Email, Role: role}, nil
+}
+
+func (s *authService) GetUser(ctx context.Context, id uint) (*models.User, error) {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *authService) DeleteExpiredRefreshTokens(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpiredRefreshTokens(ctx)
+}
+
+// signingKey returns the HMAC key of access tokens. jwt accepts an empty key,
+// so a missing or short secret is refused here as well as at startup.
+func (s *authService) signingKey() ([]byte, error) {
+	if err := s.config.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(s.config.JWTSecret), nil
+}
+
+// issueTokens signs an access token for the user and stores a new refresh
+// token in the given family
+func (s *authService) issueTokens(ctx context.Context, user *models.User, familyID string) (*models.AuthTokens, error) {
+	now := time.Now().UTC()
+	claims := accessClaims{
+		Email: user.Email,
+		Role:  user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.config.JWTIssuer,
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.config.AccessTokenTTL)),
+			ID:        uuid.NewString(),
+		},
+	}
+	key, err := s.signingKey()
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	raw := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(raw)
+
+	err = s.repo.CreateRefreshToken(ctx, &models.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(refreshToken),
+		FamilyID:  familyID,
+		ExpiresAt: now.Add(s.config.RefreshTokenTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return &models.AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.config.AccessTokenTTL.Seconds()),
+		User:         user,
+	}, nil
+}
+
+// StartRefreshTokenCleanup periodically deletes expired refresh tokens until
+// ctx is cancelled
+func StartRefreshTokenCleanup(ctx context.Context, service AuthService, interval time.Duration, logger *logrus.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := service.DeleteExpiredRefreshTokens(ctx)
+			if err != nil {
+				logger.WithError(err).Warn("Failed to delete expired refresh tokens")
+				continue
+			}
+			if deleted > 0 {
+				logger.WithField("deleted", deleted).Info("Expired refresh tokens deleted")
+			}
+		}
+	}
+}
+
+// dummyPasswordHash is a bcrypt hash at the default cost, compared against
+// when logging in with an unknown email
+var dummyPasswordHash = []byte("$2a$10$vXF4zuEQZDPDBR02yP6gmuzaifvoSugCQzPJfGLjaVMMLjigufCvu")
+
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return "", ErrInvalidEmail
+	}
+	return email, nil
+}
+
+// hashToken returns the SHA-256 of a refresh token or API key, hex encoded
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
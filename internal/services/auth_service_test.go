@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"movie-backend/internal/config"
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// memoryUserRepository keeps users in a map. Methods the tests don't need
+// fall through to the nil embedded interface.
+type memoryUserRepository struct {
+	repository.UserRepository
+	users map[string]*models.User
+}
+
+func newMemoryUserRepository(users ...models.User) *memoryUserRepository {
+	r := &memoryUserRepository{users: map[string]*models.User{}}
+	for i := range users {
+		user := users[i]
+		user.ID = uint(i + 1)
+		r.users[user.Email] = &user
+	}
+	return r
+}
+
+func (r *memoryUserRepository) Create(_ context.Context, user *models.User) error {
+	user.ID = uint(len(r.users) + 1)
+	stored := *user
+	r.users[user.Email] = &stored
+	return nil
+}
+
+func (r *memoryUserRepository) FindByEmail(_ context.Context, email string) (*models.User, error) {
+	return r.users[email], nil
+}
+
+const testJWTSecret = "0123456789abcdef0123456789abcdef"
+
+func newTestAuthService(t *testing.T, repo repository.UserRepository, cfg config.AuthConfig) AuthService {
+	t.Helper()
+	if cfg.JWTSecret == "" {
+		cfg.JWTSecret = testJWTSecret
+	}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	service, err := NewAuthService(repo, nil, &cfg, logger)
+	if err != nil {
+		t.Fatalf("NewAuthService: %v", err)
+	}
+	return service
+}
+
+func TestRegisterNeverGrantsAdmin(t *testing.T) {
+	repo := newMemoryUserRepository()
+	service := newTestAuthService(t, repo, config.AuthConfig{AdminEmail: "admin@example.com", AdminPassword: "admin-password"})
+
+	user, err := service.Register(context.Background(), "Admin@Example.com", "attacker-password", "Mallory")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if user.Role != models.RoleViewer {
+		t.Errorf("registering the ADMIN_EMAIL address gave role %q, want %q", user.Role, models.RoleViewer)
+	}
+}
+
+func TestSeedAdmin(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.AuthConfig
+		existing []models.User
+		wantRole string // role of admin@example.com afterwards; empty if it shouldn't exist
+	}{
+		{
+			name:     "creates a missing admin",
+			cfg:      config.AuthConfig{AdminEmail: "Admin@Example.com", AdminPassword: "admin-password"},
+			wantRole: models.RoleAdmin,
+		},
+		{
+			name:     "does not promote an existing account",
+			cfg:      config.AuthConfig{AdminEmail: "admin@example.com", AdminPassword: "admin-password"},
+			existing: []models.User{{Email: "admin@example.com", Role: models.RoleViewer}},
+			wantRole: models.RoleViewer,
+		},
+		{
+			name:     "keeps an existing admin",
+			cfg:      config.AuthConfig{AdminEmail: "admin@example.com", AdminPassword: "admin-password"},
+			existing: []models.User{{Email: "admin@example.com", Role: models.RoleAdmin}},
+			wantRole: models.RoleAdmin,
+		},
+		{
+			name: "does nothing without a password",
+			cfg:  config.AuthConfig{AdminEmail: "admin@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryUserRepository(tt.existing...)
+			service := newTestAuthService(t, repo, tt.cfg)
+
+			if err := service.SeedAdmin(context.Background()); err != nil {
+				t.Fatalf("SeedAdmin: %v", err)
+			}
+
+			user := repo.users["admin@example.com"]
+			switch {
+			case tt.wantRole == "" && user != nil:
+				t.Errorf("SeedAdmin created %q, want no account", user.Email)
+			case tt.wantRole != "" && user == nil:
+				t.Errorf("admin@example.com doesn't exist, want role %q", tt.wantRole)
+			case user != nil && user.Role != tt.wantRole:
+				t.Errorf("admin@example.com has role %q, want %q", user.Role, tt.wantRole)
+			}
+		})
+	}
+}
+
+// signTestToken signs an access token for an admin with key
+func signTestToken(t *testing.T, key string) string {
+	t.Helper()
+	now := time.Now()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims{
+		Email: "mallory@example.com",
+		Role:  models.RoleAdmin,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "1",
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	}).SignedString([]byte(key))
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+	return token
+}
+
+func TestAccessTokensSignedWithAnEmptyKeyAreRejected(t *testing.T) {
+	token := signTestToken(t, "")
+
+	service := newTestAuthService(t, newMemoryUserRepository(), config.AuthConfig{})
+	if _, err := service.VerifyAccessToken(token); !errors.Is(err, ErrInvalidAccessToken) {
+		t.Errorf("VerifyAccessToken returned %v, want %v", err, ErrInvalidAccessToken)
+	}
+
+	// A service that was somehow built without a secret doesn't accept it either
+	unconfigured := &authService{config: &config.AuthConfig{}, logger: discardLogger()}
+	if identity, err := unconfigured.VerifyAccessToken(token); err == nil {
+		t.Errorf("VerifyAccessToken without a secret accepted the token as %+v", identity)
+	}
+
+	if _, err := service.VerifyAccessToken(signTestToken(t, testJWTSecret)); err != nil {
+		t.Errorf("VerifyAccessToken rejected a token signed with the secret: %v", err)
+	}
+}
+
+func TestNewAuthServiceRequiresASecret(t *testing.T) {
+	for _, secret := range []string{"", "too-short"} {
+		if _, err := NewAuthService(newMemoryUserRepository(), nil, &config.AuthConfig{JWTSecret: secret}, discardLogger()); err == nil {
+			t.Errorf("NewAuthService accepted JWT secret %q", secret)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"movie-backend/internal/models"
+)
+
+var ErrCollectionNotFound = errors.New("collection not found")
+
+func (s *movieService) GetCollections(ctx context.Context, search string, page, limit int) ([]models.CollectionWithStats, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return s.collectionRepo.FindAllWithStats(ctx, search, page, limit)
+}
+
+func (s *movieService) GetCollection(ctx context.Context, id uint) (*models.CollectionWithStats, error) {
+	collection, err := s.collectionRepo.FindByIDWithStats(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if collection == nil {
+		return nil, ErrCollectionNotFound
+	}
+	return collection, nil
+}
+
+// GetCollectionMovies lists the movies of a collection with the regular list filters
+func (s *movieService) GetCollectionMovies(ctx context.Context, id uint, filter models.MovieFilter, projection models.MovieProjection) ([]models.Movie, int64, error) {
+	if _, err := s.GetCollection(ctx, id); err != nil {
+		return nil, 0, err
+	}
+
+	filter.CollectionID = id
+	return s.GetAllMovies(ctx, filter, projection)
+}
+
+// syncMovieCollection stores the collection from a movie's TMDB details,
+// fetching the collection itself once per sync (tracked in synced by TMDB
+// collection ID). It returns nil for movies without a collection.
+func (s *movieService) syncMovieCollection(ctx context.Context, summary *models.TMDBCollectionSummary, synced map[int]uint) (*uint, error) {
+	if summary == nil || summary.ID == 0 {
+		return nil, nil
+	}
+	if id, ok := synced[summary.ID]; ok {
+		return &id, nil
+	}
+
+	collection := &models.Collection{
+		TMDBID:       summary.ID,
+		Name:         summary.Name,
+		PosterPath:   summary.PosterPath,
+		BackdropPath: summary.BackdropPath,
+	}
+
+	var response models.TMDBCollectionResponse
+	if err := s.fetchTMDB(ctx, fmt.Sprintf("/collection/%d", summary.ID), &response); err != nil {
+		// The summary is enough to link the movie; the overview can wait for the next sync
+		s.logger.WithError(err).WithField("collection_id", summary.ID).Warn("Error fetching collection")
+	} else {
+		collection.Overview = response.Overview
+		if response.Name != "" {
+			collection.Name = response.Name
+		}
+	}
+
+	if err := s.collectionRepo.Upsert(ctx, collection); err != nil {
+		return nil, fmt.Errorf("failed to save collection: %w", err)
+	}
+	synced[summary.ID] = collection.ID
+	return &collection.ID, nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"movie-backend/internal/models"
+)
+
+var (
+	ErrGenreNotFound     = errors.New("genre not found")
+	ErrGenreNameRequired = errors.New("genre name is required")
+	ErrGenreNameExists   = errors.New("a genre with this name already exists")
+	ErrGenreSelfMerge    = errors.New("a genre cannot be merged into itself")
+)
+
+func (s *movieService) GetGenres(ctx context.Context) ([]models.GenreWithCount, error) {
+	return s.genreRepo.FindAllWithMovieCount(ctx)
+}
+
+func (s *movieService) GetGenreByID(ctx context.Context, id uint) (*models.GenreWithCount, error) {
+	genre, err := s.genreRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if genre == nil {
+		return nil, ErrGenreNotFound
+	}
+
+	count, err := s.genreRepo.CountMovies(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count genre movies: %w", err)
+	}
+	return &models.GenreWithCount{Genre: *genre, MovieCount: count}, nil
+}
+
+// GetGenreMovies lists the movies of a genre with the regular list filters
+func (s *movieService) GetGenreMovies(ctx context.Context, id uint, filter models.MovieFilter, projection models.MovieProjection) ([]models.Movie, int64, error) {
+	genre, err := s.genreRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	if genre == nil {
+		return nil, 0, ErrGenreNotFound
+	}
+
+	filter.GenreID = id
+	return s.GetAllMovies(ctx, filter, projection)
+}
+
+func (s *movieService) CreateGenre(ctx context.Context, name string) (*models.Genre, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrGenreNameRequired
+	}
+	if err := s.ensureGenreNameAvailable(ctx, name, 0); err != nil {
+		return nil, err
+	}
+
+	genre := &models.Genre{Name: name}
+	if err := s.genreRepo.Create(ctx, genre); err != nil {
+		return nil, err
+	}
+	return genre, nil
+}
+
+func (s *movieService) RenameGenre(ctx context.Context, id uint, name string) (*models.Genre, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrGenreNameRequired
+	}
+
+	genre, err := s.genreRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if genre == nil {
+		return nil, ErrGenreNotFound
+	}
+	if err := s.ensureGenreNameAvailable(ctx, name, id); err != nil {
+		return nil, err
+	}
+
+	genre.Name = name
+	if err := s.genreRepo.Update(ctx, genre); err != nil {
+		return nil, err
+	}
+
+	s.invalidateCatalog(ctx)
+	return genre, nil
+}
+
+// MergeGenres moves all movies of the source genre into the target genre and
+// deletes the source
+func (s *movieService) MergeGenres(ctx context.Context, sourceID, targetID uint) (*models.GenreWithCount, error) {
+	if sourceID == targetID {
+		return nil, ErrGenreSelfMerge
+	}
+	for _, id := range []uint{sourceID, targetID} {
+		genre, err := s.genreRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if genre == nil {
+			return nil, ErrGenreNotFound
+		}
+	}
+
+	if err := s.genreRepo.Merge(ctx, sourceID, targetID); err != nil {
+		return nil, err
+	}
+
+	s.invalidateCatalog(ctx)
+	return s.GetGenreByID(ctx, targetID)
+}
+
+func (s *movieService) ensureGenreNameAvailable(ctx context.Context, name string, id uint) error {
+	existing, err := s.genreRepo.FindByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to check existing genre: %w", err)
+	}
+	if existing != nil && existing.ID != id {
+		return ErrGenreNameExists
+	}
+	return nil
+}
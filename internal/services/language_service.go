@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"movie-backend/internal/models"
+	"movie-backend/internal/utils"
+)
+
+var (
+	ErrLanguageNotFound            = errors.New("language not found")
+	ErrLanguageNameRequired        = errors.New("language name is required")
+	ErrLanguageTranslationNotFound = errors.New("language translation not found")
+	ErrInvalidLocale               = errors.New("locale must be a language tag such as id or pt-BR")
+)
+
+// GetLanguages lists all languages with their movie counts. The display name
+// is taken from the first of the given locales that has a translation.
+func (s *movieService) GetLanguages(ctx context.Context, locales []string) ([]models.LanguageWithCount, error) {
+	languages, err := s.langRepo.FindAllWithMovieCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range languages {
+		languages[i].DisplayName = languages[i].LocalizedName(locales)
+	}
+	return languages, nil
+}
+
+func (s *movieService) GetLanguage(ctx context.Context, id uint, locales []string) (*models.LanguageWithCount, error) {
+	language, err := s.langRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if language == nil {
+		return nil, ErrLanguageNotFound
+	}
+
+	count, err := s.langRepo.CountMovies(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count language movies: %w", err)
+	}
+	return &models.LanguageWithCount{
+		Language:    *language,
+		DisplayName: language.LocalizedName(locales),
+		MovieCount:  count,
+	}, nil
+}
+
+// UpdateLanguageName changes the English name of a language
+func (s *movieService) UpdateLanguageName(ctx context.Context, id uint, name string) (*models.Language, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrLanguageNameRequired
+	}
+
+	language, err := s.langRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if language == nil {
+		return nil, ErrLanguageNotFound
+	}
+
+	language.Name = name
+	if err := s.langRepo.Update(ctx, language); err != nil {
+		return nil, err
+	}
+
+	s.invalidateCatalog(ctx)
+	return language, nil
+}
+
+// SetLanguageTranslation creates or replaces the name of a language in a locale
+func (s *movieService) SetLanguageTranslation(ctx context.Context, id uint, locale, name string) (*models.LanguageTranslation, error) {
+	locale, ok := utils.NormalizeLocale(locale)
+	if !ok {
+		return nil, ErrInvalidLocale
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrLanguageNameRequired
+	}
+
+	language, err := s.langRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if language == nil {
+		return nil, ErrLanguageNotFound
+	}
+
+	translation := &models.LanguageTranslation{
+		LanguageID: id,
+		Locale:     locale,
+		Name:       name,
+	}
+	if err := s.langRepo.UpsertTranslation(ctx, translation); err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+func (s *movieService) DeleteLanguageTranslation(ctx context.Context, id uint, locale string) error {
+	locale, ok := utils.NormalizeLocale(locale)
+	if !ok {
+		return ErrInvalidLocale
+	}
+
+	deleted, err := s.langRepo.DeleteTranslation(ctx, id, locale)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrLanguageTranslationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,443 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"movie-backend/internal/audit"
+	"movie-backend/internal/constants"
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// errImportAborted rolls back an atomic import after a row has failed
+var errImportAborted = errors.New("import aborted")
+
+// importCandidate is a validated import row ready to be written
+type importCandidate struct {
+	result    *models.MovieImportRowResult
+	movie     *models.Movie
+	genres    []models.Genre
+	hasGenres bool
+	present   map[string]bool
+	// language is an original language that doesn't exist yet. It is created
+	// when the row is written, so a rejected import leaves no language behind.
+	language *models.Language
+}
+
+// ImportMovies validates and writes a batch of imported rows. Rows are created,
+// or updated by TMDB ID when opts.Upsert is set. With opts.Atomic the whole
+// batch runs in one transaction and is rolled back if any row fails.
+func (s *movieService) ImportMovies(ctx context.Context, records []models.MovieImportRecord, opts models.MovieImportOptions) (*models.MovieImportReport, error) {
+	if len(records) > constants.MaxImportRows {
+		return nil, fmt.Errorf("import is limited to %d rows, got %d", constants.MaxImportRows, len(records))
+	}
+
+	report := &models.MovieImportReport{
+		Options:   opts,
+		TotalRows: len(records),
+		Rows:      make([]models.MovieImportRowResult, len(records)),
+	}
+
+	ctx = audit.WithSource(ctx, audit.SourceImport)
+	resolver := newImportResolver(s)
+	candidates := make([]*importCandidate, 0, len(records))
+	invalid := 0
+
+	for i, record := range records {
+		report.Rows[i] = models.MovieImportRowResult{Row: record.Row}
+		result := &report.Rows[i]
+
+		candidate, errs := s.prepareImportRecord(ctx, resolver, record, opts)
+		if candidate != nil {
+			result.TMDBID = candidate.movie.TMDBID
+			result.Title = candidate.movie.Title
+		}
+		if len(errs) > 0 {
+			result.Status = models.ImportStatusFailed
+			result.Errors = errs
+			invalid++
+			continue
+		}
+
+		candidate.result = result
+		candidates = append(candidates, candidate)
+	}
+
+	// In atomic mode a single invalid row means nothing is written
+	if opts.Atomic && invalid > 0 {
+		for _, candidate := range candidates {
+			candidate.result.Status = models.ImportStatusRolledBack
+		}
+		report.RolledBack = true
+		s.summarizeImport(report)
+		return report, nil
+	}
+
+	write := func(repo repository.MovieRepository) error {
+		for _, candidate := range candidates {
+			if err := s.writeImportCandidate(ctx, repo, candidate, opts); err != nil {
+				candidate.result.Status = models.ImportStatusFailed
+				candidate.result.Errors = append(candidate.result.Errors, err.Error())
+				if opts.Atomic {
+					return errImportAborted
+				}
+			}
+		}
+		return nil
+	}
+
+	if opts.Atomic {
+		err := s.repo.Transaction(ctx, write)
+		if err != nil && !errors.Is(err, errImportAborted) {
+			return nil, fmt.Errorf("import transaction failed: %w", err)
+		}
+		if err != nil {
+			for _, candidate := range candidates {
+				if candidate.result.Status != models.ImportStatusFailed {
+					candidate.result.Status = models.ImportStatusRolledBack
+					candidate.result.MovieID = 0
+				}
+			}
+			report.RolledBack = true
+		}
+	} else {
+		_ = write(s.repo)
+	}
+
+	s.summarizeImport(report)
+	if report.Created+report.Updated > 0 {
+		s.invalidateCatalog(ctx)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"total_rows":  report.TotalRows,
+		"created":     report.Created,
+		"updated":     report.Updated,
+		"failed":      report.Failed,
+		"rolled_back": report.RolledBack,
+	}).Info("Movie import completed")
+
+	return report, nil
+}
+
+func (s *movieService) summarizeImport(report *models.MovieImportReport) {
+	report.Created, report.Updated, report.Failed = 0, 0, 0
+	for _, row := range report.Rows {
+		switch row.Status {
+		case models.ImportStatusCreated:
+			report.Created++
+		case models.ImportStatusUpdated:
+			report.Updated++
+		case models.ImportStatusFailed:
+			report.Failed++
+		}
+	}
+}
+
+// writeImportCandidate writes a row and its revision in one transaction
+func (s *movieService) writeImportCandidate(ctx context.Context, repo repository.MovieRepository, candidate *importCandidate, opts models.MovieImportOptions) error {
+	return repo.Transaction(ctx, func(repo repository.MovieRepository) error {
+		return s.writeImportRow(ctx, repo, candidate, opts)
+	})
+}
+
+func (s *movieService) writeImportRow(ctx context.Context, repo repository.MovieRepository, candidate *importCandidate, opts models.MovieImportOptions) error {
+	movie := candidate.movie
+
+	if candidate.language != nil {
+		language, err := repo.FindOrCreateLanguage(ctx, candidate.language.Code, candidate.language.Name)
+		if err != nil {
+			return fmt.Errorf("failed to create language %q: %w", candidate.language.Code, err)
+		}
+		movie.LanguageID = &language.ID
+	}
+
+	if movie.TMDBID > 0 {
+		existing, err := repo.FindByTMDBID(ctx, movie.TMDBID)
+		if err != nil {
+			return fmt.Errorf("failed to check existing movie: %w", err)
+		}
+		if existing != nil && existing.DeletedAt.Valid {
+			return fmt.Errorf("movie with TMDB ID %d is in the trash", movie.TMDBID)
+		}
+		if existing != nil {
+			if !opts.Upsert {
+				return fmt.Errorf("movie with TMDB ID %d already exists", movie.TMDBID)
+			}
+
+			before := existing.AuditState()
+			existing.Genres = nil // genres are replaced separately below
+			mergeImportedFields(existing, movie, candidate.present)
+			if existing.Title == "" {
+				return fmt.Errorf("movie title is required")
+			}
+			if err := repo.Update(ctx, existing); err != nil {
+				return fmt.Errorf("failed to update movie: %w", err)
+			}
+			if candidate.hasGenres {
+				if err := repo.ReplaceGenres(ctx, existing, candidate.genres); err != nil {
+					return fmt.Errorf("failed to update genres: %w", err)
+				}
+			}
+			if err := s.recordRevision(ctx, repo, existing.ID, models.RevisionActionUpdate, &before, nil); err != nil {
+				return fmt.Errorf("failed to record revision: %w", err)
+			}
+
+			candidate.result.Status = models.ImportStatusUpdated
+			candidate.result.MovieID = existing.ID
+			candidate.result.Title = existing.Title
+			return nil
+		}
+	}
+
+	if movie.Title == "" {
+		return fmt.Errorf("movie title is required")
+	}
+
+	movie.Genres = candidate.genres
+	if err := repo.Create(ctx, movie); err != nil {
+		return fmt.Errorf("failed to create movie: %w", err)
+	}
+	if err := s.recordRevision(ctx, repo, movie.ID, models.RevisionActionCreate, nil, nil); err != nil {
+		return fmt.Errorf("failed to record revision: %w", err)
+	}
+
+	candidate.result.Status = models.ImportStatusCreated
+	candidate.result.MovieID = movie.ID
+	return nil
+}
+
+// prepareImportRecord parses and validates the raw values of a row, resolving
+// language and genre names to their IDs without writing anything
+func (s *movieService) prepareImportRecord(ctx context.Context, resolver *importResolver, record models.MovieImportRecord, opts models.MovieImportOptions) (*importCandidate, []string) {
+	candidate := &importCandidate{
+		movie:   &models.Movie{},
+		present: make(map[string]bool),
+	}
+	var errs []string
+
+	for _, column := range models.MovieImportColumns {
+		// Blank cells are treated as missing so upserts keep the stored value
+		value := strings.TrimSpace(record.Fields[column])
+		if value == "" {
+			continue
+		}
+		candidate.present[column] = true
+		movie := candidate.movie
+
+		switch column {
+		case "tmdb_id":
+			id, err := strconv.Atoi(value)
+			if err != nil || id < 0 {
+				errs = append(errs, fmt.Sprintf("invalid tmdb_id %q", value))
+				continue
+			}
+			movie.TMDBID = id
+		case "title":
+			movie.Title = value
+		case "original_title":
+			movie.OriginalTitle = value
+		case "overview":
+			movie.Overview = value
+		case "release_date":
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				errs = append(errs, fmt.Sprintf("invalid release_date %q, expected YYYY-MM-DD", value))
+				continue
+			}
+			movie.ReleaseDate = value
+		case "poster_path":
+			movie.PosterPath = value
+		case "backdrop_path":
+			movie.BackdropPath = value
+		case "vote_average":
+			avg, err := strconv.ParseFloat(value, 64)
+			if err != nil || avg < 0 || avg > 10 {
+				errs = append(errs, fmt.Sprintf("invalid vote_average %q, expected 0-10", value))
+				continue
+			}
+			movie.VoteAverage = avg
+		case "vote_count":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 0 {
+				errs = append(errs, fmt.Sprintf("invalid vote_count %q", value))
+				continue
+			}
+			movie.VoteCount = count
+		case "popularity":
+			popularity, err := strconv.ParseFloat(value, 64)
+			if err != nil || popularity < 0 {
+				errs = append(errs, fmt.Sprintf("invalid popularity %q", value))
+				continue
+			}
+			movie.Popularity = popularity
+		case "adult":
+			adult, err := parseImportBool(value)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("invalid adult flag %q", value))
+				continue
+			}
+			movie.Adult = adult
+		case "original_language":
+			language, err := resolver.language(ctx, value)
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			if language.ID == 0 {
+				candidate.language = language
+			} else {
+				movie.LanguageID = &language.ID
+			}
+		case "genres":
+			candidate.hasGenres = true
+			for _, name := range splitImportList(value) {
+				genre, err := resolver.genre(ctx, name)
+				if err != nil {
+					errs = append(errs, err.Error())
+					continue
+				}
+				candidate.genres = append(candidate.genres, *genre)
+			}
+		}
+	}
+
+	// Upserts may leave the title out and keep the stored one
+	if candidate.movie.Title == "" && (!opts.Upsert || candidate.movie.TMDBID == 0) {
+		errs = append(errs, "title is required")
+	}
+
+	return candidate, errs
+}
+
+// mergeImportedFields copies the columns present in the import row onto dst
+func mergeImportedFields(dst, src *models.Movie, present map[string]bool) {
+	for column := range present {
+		switch column {
+		case "title":
+			dst.Title = src.Title
+		case "original_title":
+			dst.OriginalTitle = src.OriginalTitle
+		case "overview":
+			dst.Overview = src.Overview
+		case "release_date":
+			dst.ReleaseDate = src.ReleaseDate
+		case "poster_path":
+			dst.PosterPath = src.PosterPath
+		case "backdrop_path":
+			dst.BackdropPath = src.BackdropPath
+		case "vote_average":
+			dst.VoteAverage = src.VoteAverage
+		case "vote_count":
+			dst.VoteCount = src.VoteCount
+		case "popularity":
+			dst.Popularity = src.Popularity
+		case "adult":
+			dst.Adult = src.Adult
+		case "original_language":
+			if src.LanguageID != nil {
+				dst.LanguageID = src.LanguageID
+			}
+		}
+	}
+}
+
+func parseImportBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "y":
+		return true, nil
+	case "no", "n":
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// splitImportList splits a multi-valued cell such as "Action|Drama"
+func splitImportList(value string) []string {
+	parts := strings.FieldsFunc(value, func(r rune) bool {
+		return r == '|' || r == ';' || r == ','
+	})
+	var items []string
+	for _, part := range parts {
+		if item := strings.TrimSpace(part); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// importResolver caches language and genre lookups for the duration of an import
+type importResolver struct {
+	service   *movieService
+	languages map[string]*models.Language
+	genres    map[string]*models.Genre
+}
+
+func newImportResolver(s *movieService) *importResolver {
+	return &importResolver{
+		service:   s,
+		languages: make(map[string]*models.Language),
+		genres:    make(map[string]*models.Genre),
+	}
+}
+
+// language resolves an ISO 639-1 code or an English language name. It only
+// reads: a code that isn't stored yet resolves to an unsaved language with
+// ID 0, which is created when the row is written.
+func (r *importResolver) language(ctx context.Context, value string) (*models.Language, error) {
+	key := strings.ToLower(value)
+	if language, ok := r.languages[key]; ok {
+		return language, nil
+	}
+
+	var language *models.Language
+	var err error
+	if len(key) <= 3 {
+		language, err = r.service.langRepo.FindByCode(ctx, key)
+		if err == nil && language == nil {
+			language = &models.Language{Code: key, Name: r.service.getLanguageName(key)}
+		}
+	} else {
+		language, err = r.service.langRepo.FindByName(ctx, value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve language %q: %v", value, err)
+	}
+	if language == nil {
+		return nil, fmt.Errorf("unknown language %q", value)
+	}
+
+	r.languages[key] = language
+	return language, nil
+}
+
+// genre resolves a genre by name, or by TMDB genre ID when the value is numeric
+func (r *importResolver) genre(ctx context.Context, value string) (*models.Genre, error) {
+	key := strings.ToLower(value)
+	if genre, ok := r.genres[key]; ok {
+		return genre, nil
+	}
+
+	var genre *models.Genre
+	var err error
+	if tmdbID, convErr := strconv.Atoi(value); convErr == nil {
+		genre, err = r.service.genreRepo.FindByTMDBID(ctx, tmdbID)
+	} else {
+		genre, err = r.service.genreRepo.FindByName(ctx, value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve genre %q: %v", value, err)
+	}
+	if genre == nil {
+		return nil, fmt.Errorf("unknown genre %q", value)
+	}
+
+	r.genres[key] = genre
+	return genre, nil
+}
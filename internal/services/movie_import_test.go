@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"movie-backend/internal/config"
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+)
+
+// importMovieRepository stores movies and languages in memory. Transaction
+// restores both when fn fails, like a rollback.
+type importMovieRepository struct {
+	repository.MovieRepository
+	movies    []models.Movie
+	languages []models.Language
+}
+
+func (r *importMovieRepository) Transaction(_ context.Context, fn func(repo repository.MovieRepository) error) error {
+	movies := append([]models.Movie(nil), r.movies...)
+	languages := append([]models.Language(nil), r.languages...)
+	if err := fn(r); err != nil {
+		r.movies, r.languages = movies, languages
+		return err
+	}
+	return nil
+}
+
+func (r *importMovieRepository) FindOrCreateLanguage(_ context.Context, code, name string) (*models.Language, error) {
+	for _, language := range r.languages {
+		if language.Code == code {
+			return &language, nil
+		}
+	}
+	language := models.Language{ID: uint(len(r.languages) + 100), Code: code, Name: name}
+	r.languages = append(r.languages, language)
+	return &language, nil
+}
+
+func (r *importMovieRepository) FindByTMDBID(context.Context, int) (*models.Movie, error) {
+	return nil, nil
+}
+
+func (r *importMovieRepository) Create(_ context.Context, movie *models.Movie) error {
+	movie.ID = uint(len(r.movies) + 1)
+	r.movies = append(r.movies, *movie)
+	return nil
+}
+
+func (r *importMovieRepository) FindByID(_ context.Context, id uint) (*models.Movie, error) {
+	movie := r.movies[id-1]
+	return &movie, nil
+}
+
+func (r *importMovieRepository) CreateRevision(context.Context, *models.MovieRevision) error {
+	return nil
+}
+
+func (r *importMovieRepository) BumpCatalogVersion(context.Context) error {
+	return nil
+}
+
+// readOnlyLanguageRepository knows no languages and fails the test when the
+// import writes through it instead of its transaction
+type readOnlyLanguageRepository struct {
+	repository.LanguageRepository
+	t *testing.T
+}
+
+func (readOnlyLanguageRepository) FindByCode(context.Context, string) (*models.Language, error) {
+	return nil, nil
+}
+
+func (r readOnlyLanguageRepository) FindOrCreate(context.Context, string, string) (*models.Language, error) {
+	r.t.Error("language created outside the import transaction")
+	return nil, nil
+}
+
+func TestImportCreatesLanguagesWithTheRows(t *testing.T) {
+	valid := models.MovieImportRecord{Row: 2, Fields: map[string]string{"title": "Laskar Pelangi", "original_language": "id"}}
+	untitled := models.MovieImportRecord{Row: 3, Fields: map[string]string{"original_language": "th"}}
+
+	tests := []struct {
+		name          string
+		records       []models.MovieImportRecord
+		opts          models.MovieImportOptions
+		wantMovies    int
+		wantLanguages []string
+	}{
+		{"rejected atomic import", []models.MovieImportRecord{valid, untitled}, models.MovieImportOptions{Atomic: true}, 0, nil},
+		{"atomic import", []models.MovieImportRecord{valid}, models.MovieImportOptions{Atomic: true}, 1, []string{"id"}},
+		{"invalid row of a partial import", []models.MovieImportRecord{valid, untitled}, models.MovieImportOptions{}, 1, []string{"id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &importMovieRepository{}
+			service := &movieService{
+				repo:     repo,
+				langRepo: readOnlyLanguageRepository{t: t},
+				config:   &config.Config{},
+				logger:   discardLogger(),
+				cache:    NewMemoryCache(10),
+			}
+
+			if _, err := service.ImportMovies(context.Background(), tt.records, tt.opts); err != nil {
+				t.Fatalf("ImportMovies: %v", err)
+			}
+			if len(repo.movies) != tt.wantMovies {
+				t.Errorf("got %d movies, want %d", len(repo.movies), tt.wantMovies)
+			}
+			var codes []string
+			for _, language := range repo.languages {
+				codes = append(codes, language.Code)
+			}
+			if len(codes) != len(tt.wantLanguages) || (len(codes) > 0 && codes[0] != tt.wantLanguages[0]) {
+				t.Errorf("got languages %v, want %v", codes, tt.wantLanguages)
+			}
+			for _, movie := range repo.movies {
+				if movie.LanguageID == nil || *movie.LanguageID != repo.languages[0].ID {
+					t.Errorf("movie %q has language %v, want the created language %d", movie.Title, movie.LanguageID, repo.languages[0].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestImportRollsBackCreatedLanguages(t *testing.T) {
+	repo := &importMovieRepository{}
+	service := &movieService{
+		repo:     repo,
+		langRepo: readOnlyLanguageRepository{t: t},
+		config:   &config.Config{},
+		logger:   discardLogger(),
+		cache:    NewMemoryCache(10),
+	}
+
+	// The second row passes validation but fails to write, after the first
+	// row created its language
+	records := []models.MovieImportRecord{
+		{Row: 2, Fields: map[string]string{"title": "Laskar Pelangi", "original_language": "id"}},
+		{Row: 3, Fields: map[string]string{"tmdb_id": "1", "original_language": "th"}},
+	}
+	report, err := service.ImportMovies(context.Background(), records, models.MovieImportOptions{Atomic: true, Upsert: true})
+	if err != nil {
+		t.Fatalf("ImportMovies: %v", err)
+	}
+	if !report.RolledBack {
+		t.Fatalf("import was not rolled back: %+v", report.Rows)
+	}
+	if len(repo.movies) != 0 || len(repo.languages) != 0 {
+		t.Errorf("rolled back import left %d movies and languages %v", len(repo.movies), repo.languages)
+	}
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"movie-backend/internal/audit"
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+)
+
+var (
+	ErrFieldLockNotFound = errors.New("field is not locked")
+	ErrInvalidLockField  = errors.New("unknown lockable field")
+)
+
+func (s *movieService) GetMovieLocks(ctx context.Context, id uint) ([]models.MovieFieldLock, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.FindFieldLocks(ctx, id)
+}
+
+// LockMovieFields locks fields so TMDB sync leaves them unchanged
+func (s *movieService) LockMovieFields(ctx context.Context, id uint, fields []string) ([]models.MovieFieldLock, error) {
+	for _, field := range fields {
+		if !models.IsLockableMovieField(field) {
+			return nil, fmt.Errorf("%w %q, expected one of %s", ErrInvalidLockField, field, strings.Join(models.LockableMovieFields, ", "))
+		}
+	}
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.LockFields(ctx, id, fields, audit.ActorFrom(ctx).Name); err != nil {
+		return nil, err
+	}
+	return s.repo.FindFieldLocks(ctx, id)
+}
+
+func (s *movieService) UnlockMovieField(ctx context.Context, id uint, field string) error {
+	unlocked, err := s.repo.UnlockField(ctx, id, field)
+	if err != nil {
+		return err
+	}
+	if !unlocked {
+		return ErrFieldLockNotFound
+	}
+	return nil
+}
+
+// lockEditedFields locks the fields an API edit changed from before to after,
+// so the next sync doesn't undo the edit
+func (s *movieService) lockEditedFields(ctx context.Context, repo repository.MovieRepository, id uint, before, after *models.MovieAuditState) error {
+	changes, err := models.DiffAuditStates(before, after)
+	if err != nil {
+		return fmt.Errorf("failed to diff movie: %w", err)
+	}
+	if err := repo.LockFields(ctx, id, models.ChangedFields(changes), audit.ActorFrom(ctx).Name); err != nil {
+		return fmt.Errorf("failed to lock edited fields: %w", err)
+	}
+	return nil
+}
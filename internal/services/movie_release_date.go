@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"movie-backend/internal/models"
+)
+
+// GetMovieReleaseDates returns the release dates of a movie, optionally only
+// those of one country
+func (s *movieService) GetMovieReleaseDates(ctx context.Context, id uint, country string) ([]models.MovieReleaseDate, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.FindReleaseDates(ctx, id, strings.ToUpper(country))
+}
+
+// releaseDatesFromTMDB converts the TMDB release dates and certifications of
+// a movie in every country
+func releaseDatesFromTMDB(response *models.TMDBReleaseDatesResponse) []models.MovieReleaseDate {
+	var releaseDates []models.MovieReleaseDate
+	for _, country := range response.Results {
+		for _, r := range country.ReleaseDates {
+			// TMDB sends timestamps such as 2019-04-24T00:00:00.000Z; only the
+			// date is kept, like Movie.ReleaseDate
+			if len(r.ReleaseDate) < 10 {
+				continue
+			}
+			releaseDates = append(releaseDates, models.MovieReleaseDate{
+				Country:       strings.ToUpper(country.ISO31661),
+				Type:          r.Type,
+				Certification: strings.TrimSpace(r.Certification),
+				ReleaseDate:   r.ReleaseDate[:10],
+				Language:      r.ISO6391,
+				Note:          r.Note,
+			})
+		}
+	}
+	return releaseDates
+}
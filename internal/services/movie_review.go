@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"movie-backend/internal/constants"
+	"movie-backend/internal/models"
+)
+
+const maxReviewTitleLength = 200
+
+var (
+	ErrInvalidRating       = errors.New("rating must be between 1 and 10")
+	ErrReviewTitleTooLong  = errors.New("review title cannot be longer than 200 characters")
+	ErrReviewNotFound      = errors.New("review not found")
+	ErrInvalidReviewStatus = errors.New("status must be pending, approved or rejected")
+)
+
+func reviewPage(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = constants.DefaultPageSize
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return page, limit
+}
+
+// GetMovieReviews lists the approved reviews of a live movie, newest first
+func (s *movieService) GetMovieReviews(ctx context.Context, movieID uint, page, limit int) ([]models.MovieReview, int64, error) {
+	if _, err := s.repo.FindByID(ctx, movieID); err != nil {
+		return nil, 0, err
+	}
+
+	page, limit = reviewPage(page, limit)
+	return s.reviewRepo.FindAll(ctx, models.ReviewFilter{
+		MovieID: movieID,
+		Status:  models.ReviewStatusApproved,
+		Page:    page,
+		Limit:   limit,
+	})
+}
+
+// GetUserReviews lists the user's own reviews in every moderation state
+func (s *movieService) GetUserReviews(ctx context.Context, userID uint, page, limit int) ([]models.MovieReview, int64, error) {
+	page, limit = reviewPage(page, limit)
+	return s.reviewRepo.FindAll(ctx, models.ReviewFilter{UserID: userID, Page: page, Limit: limit})
+}
+
+func (s *movieService) GetUserReview(ctx context.Context, userID, movieID uint) (*models.MovieReview, error) {
+	review, err := s.reviewRepo.FindByUserAndMovie(ctx, userID, movieID)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, ErrReviewNotFound
+	}
+	return review, nil
+}
+
+// ReviewMovie saves the user's rating of a live movie, replacing an earlier
+// one. A rating without title or body is approved right away. Reviews with
+// text wait for moderation, unless only the rating changed since an editor
+// last moderated them.
+func (s *movieService) ReviewMovie(ctx context.Context, userID, movieID uint, rating int, title, body string) (*models.MovieReview, error) {
+	if rating < models.MinUserRating || rating > models.MaxUserRating {
+		return nil, ErrInvalidRating
+	}
+	title = strings.TrimSpace(title)
+	body = strings.TrimSpace(body)
+	if utf8.RuneCountInString(title) > maxReviewTitleLength {
+		return nil, ErrReviewTitleTooLong
+	}
+	if _, err := s.repo.FindByID(ctx, movieID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.reviewRepo.FindByUserAndMovie(ctx, userID, movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &models.MovieReview{
+		MovieID: movieID,
+		UserID:  userID,
+		Rating:  rating,
+		Title:   title,
+		Body:    body,
+		Status:  models.ReviewStatusPending,
+	}
+	switch {
+	case !review.HasText():
+		review.Status = models.ReviewStatusApproved
+	case existing != nil && existing.Title == title && existing.Body == body:
+		review.Status = existing.Status
+		review.ModeratedByID = existing.ModeratedByID
+		review.ModeratedAt = existing.ModeratedAt
+		review.ModerationNote = existing.ModerationNote
+	}
+
+	if err := s.reviewRepo.Upsert(ctx, review); err != nil {
+		return nil, err
+	}
+	s.invalidateCatalog(ctx)
+	return review, nil
+}
+
+// DeleteUserReview removes the user's rating and review of a movie
+func (s *movieService) DeleteUserReview(ctx context.Context, userID, movieID uint) error {
+	deleted, err := s.reviewRepo.Delete(ctx, userID, movieID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrReviewNotFound
+	}
+	s.invalidateCatalog(ctx)
+	return nil
+}
+
+// GetReviews lists reviews for moderation, newest first
+func (s *movieService) GetReviews(ctx context.Context, filter models.ReviewFilter) ([]models.MovieReview, int64, error) {
+	if filter.Status != "" && !models.ValidReviewStatus(filter.Status) {
+		return nil, 0, ErrInvalidReviewStatus
+	}
+	filter.Page, filter.Limit = reviewPage(filter.Page, filter.Limit)
+	return s.reviewRepo.FindAll(ctx, filter)
+}
+
+// ModerateReview approves or rejects a review, or puts it back in the queue
+// with pending. Only approved reviews count towards the movie's user rating.
+func (s *movieService) ModerateReview(ctx context.Context, id, moderatorID uint, status, note string) (*models.MovieReview, error) {
+	if !models.ValidReviewStatus(status) {
+		return nil, ErrInvalidReviewStatus
+	}
+
+	review, err := s.reviewRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, ErrReviewNotFound
+	}
+
+	now := time.Now().UTC()
+	review.Status = status
+	review.ModeratedByID = &moderatorID
+	review.ModeratedAt = &now
+	review.ModerationNote = strings.TrimSpace(note)
+	if err := s.reviewRepo.Moderate(ctx, review); err != nil {
+		return nil, err
+	}
+	s.invalidateCatalog(ctx)
+	return review, nil
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"movie-backend/internal/audit"
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// recordRevision stores a revision for a write that has just been made with
+// repo, attributed to the actor in ctx. The state after the write is read
+// back so relations that were not part of the write are captured too; a
+// delete snapshots the state before. Updates that changed nothing are not
+// recorded.
+func (s *movieService) recordRevision(ctx context.Context, repo repository.MovieRepository, movieID uint, action string, before *models.MovieAuditState, revertedFrom *int) error {
+	var after *models.MovieAuditState
+	if action != models.RevisionActionDelete {
+		movie, err := repo.FindByID(ctx, movieID)
+		if err != nil {
+			return fmt.Errorf("failed to load movie for revision: %w", err)
+		}
+		state := movie.AuditState()
+		after = &state
+	}
+
+	changes, err := models.DiffAuditStates(before, after)
+	if err != nil {
+		return fmt.Errorf("failed to diff movie: %w", err)
+	}
+	if action == models.RevisionActionUpdate && len(changes) == 0 {
+		return nil
+	}
+
+	snapshot := after
+	if snapshot == nil {
+		snapshot = before
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode revision changes: %w", err)
+	}
+	snapshotJSON, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode revision snapshot: %w", err)
+	}
+
+	actor := audit.ActorFrom(ctx)
+	return repo.CreateRevision(ctx, &models.MovieRevision{
+		MovieID:      movieID,
+		Action:       action,
+		Source:       actor.Source,
+		Actor:        actor.Name,
+		ActorRole:    actor.Role,
+		RequestID:    actor.RequestID,
+		RevertedFrom: revertedFrom,
+		Changes:      changesJSON,
+		Snapshot:     snapshotJSON,
+	})
+}
+
+// GetMovieHistory lists the revisions of a movie, newest first. The history
+// of trashed movies stays available until they are purged.
+func (s *movieService) GetMovieHistory(ctx context.Context, id uint, page, limit int) ([]models.MovieRevision, int64, error) {
+	return s.repo.FindRevisions(ctx, id, page, limit)
+}
+
+// RevertMovie restores the audited fields of a movie to their state after the
+// given revision. The revert is recorded as a new revision.
+func (s *movieService) RevertMovie(ctx context.Context, id uint, revision int) (*models.Movie, error) {
+	rev, err := s.repo.FindRevision(ctx, id, revision)
+	if err != nil {
+		return nil, err
+	}
+	if rev == nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	var state models.MovieAuditState
+	if err := json.Unmarshal(rev.Snapshot, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode revision snapshot: %w", err)
+	}
+
+	var genres []models.Genre
+	for _, genreID := range state.GenreIDs {
+		genre, err := s.genreRepo.FindByID(ctx, genreID)
+		if err != nil {
+			return nil, err
+		}
+		// Genres merged away since the revision are dropped
+		if genre != nil {
+			genres = append(genres, *genre)
+		}
+	}
+
+	err = s.repo.Transaction(ctx, func(repo repository.MovieRepository) error {
+		movie, err := repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := movie.AuditState()
+
+		movie.ApplyAuditState(state)
+		movie.Language = nil
+		movie.Genres = nil
+		if err := repo.Update(ctx, movie); err != nil {
+			return err
+		}
+		if err := repo.ReplaceGenres(ctx, movie, genres); err != nil {
+			return fmt.Errorf("failed to restore genres: %w", err)
+		}
+		reverted := state
+		reverted.TMDBID = before.TMDBID
+		if err := s.lockEditedFields(ctx, repo, id, &before, &reverted); err != nil {
+			return err
+		}
+		return s.recordRevision(ctx, repo, id, models.RevisionActionRevert, &before, &rev.Revision)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateCatalog(ctx)
+	return s.repo.FindByID(ctx, id)
+}
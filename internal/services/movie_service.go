@@ -3,65 +3,158 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"movie-backend/internal/audit"
 	"movie-backend/internal/config"
 	"movie-backend/internal/models"
 	"movie-backend/internal/repository"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
 )
 
 type MovieService interface {
 	// CRUD operations
 	CreateMovie(ctx context.Context, movie *models.Movie) error
 	UpdateMovie(ctx context.Context, id uint, movie *models.Movie) error
-	DeleteMovie(ctx context.Context, id uint) error
-	GetMovieByID(ctx context.Context, id uint) (*models.Movie, error)
-	GetAllMovies(ctx context.Context, page, limit int, search, sortBy, order, startDate, endDate string) ([]models.Movie, int64, error)
+	DeleteMovie(ctx context.Context, id uint, version int64) error
+	GetMovieByID(ctx context.Context, id uint, projection models.MovieProjection) (*models.Movie, error)
+	GetAllMovies(ctx context.Context, filter models.MovieFilter, projection models.MovieProjection) ([]models.Movie, int64, error)
+
+	// Revision operations
+	GetMovieHistory(ctx context.Context, id uint, page, limit int) ([]models.MovieRevision, int64, error)
+	RevertMovie(ctx context.Context, id uint, revision int) (*models.Movie, error)
+
+	// Video operations
+	GetMovieVideos(ctx context.Context, id uint, types []string) ([]models.MovieVideo, error)
+
+	// Release date operations
+	GetMovieReleaseDates(ctx context.Context, id uint, country string) ([]models.MovieReleaseDate, error)
+
+	// Watch provider operations
+	GetMovieProviders(ctx context.Context, id uint, region string) ([]models.MovieRegionProviders, error)
+	GetProviders(ctx context.Context, region string) ([]models.ProviderCoverage, error)
+
+	// Field lock operations
+	GetMovieLocks(ctx context.Context, id uint) ([]models.MovieFieldLock, error)
+	LockMovieFields(ctx context.Context, id uint, fields []string) ([]models.MovieFieldLock, error)
+	UnlockMovieField(ctx context.Context, id uint, field string) error
+
+	// Trash operations
+	GetTrashedMovies(ctx context.Context, filter models.MovieFilter, projection models.MovieProjection) ([]models.Movie, int64, error)
+	RestoreMovie(ctx context.Context, id uint) (*models.Movie, error)
+	PurgeTrash(ctx context.Context) (int, error)
+
+	// Import and export operations
+	ImportMovies(ctx context.Context, records []models.MovieImportRecord, opts models.MovieImportOptions) (*models.MovieImportReport, error)
+	ExportMovies(ctx context.Context, filter models.MovieFilter, fn func(row *models.MovieExportRow) error) error
 
 	// Sync operations
 	SyncMoviesFromTMDB(ctx context.Context, pages int) (*models.SyncLog, error)
 	GetLastSyncLog(ctx context.Context) (*models.SyncLog, error)
 
+	// Cache validation
+	GetCatalogState(ctx context.Context) (*models.CatalogState, error)
+
 	// Dashboard operations
-	GetDashboardStats(ctx context.Context) (*models.DashboardStats, error)
+	GetDashboardStats(ctx context.Context, region string) (*models.DashboardStats, error)
 
 	// Chart data operations
-	GetChartData(ctx context.Context, startDate, endDate string) (*models.ChartDataResponse, error)
+	GetChartData(ctx context.Context, startDate, endDate, region string) (*models.ChartDataResponse, error)
 	GetMoviesByLanguage(ctx context.Context) ([]models.PieChartData, error)
-	GetMoviesByYear(ctx context.Context, startDate, endDate string) ([]models.ColumnChartData, error)
-	GetMoviesByMonth(ctx context.Context, year int) ([]models.ColumnChartData, error)
+	GetMoviesByYear(ctx context.Context, startDate, endDate, region string) ([]models.ColumnChartData, error)
+	GetMoviesByMonth(ctx context.Context, year int, region string) ([]models.ColumnChartData, error)
+
+	// Genre operations
+	GetGenres(ctx context.Context) ([]models.GenreWithCount, error)
+	GetGenreByID(ctx context.Context, id uint) (*models.GenreWithCount, error)
+	GetGenreMovies(ctx context.Context, id uint, filter models.MovieFilter, projection models.MovieProjection) ([]models.Movie, int64, error)
+	CreateGenre(ctx context.Context, name string) (*models.Genre, error)
+	RenameGenre(ctx context.Context, id uint, name string) (*models.Genre, error)
+	MergeGenres(ctx context.Context, sourceID, targetID uint) (*models.GenreWithCount, error)
+
+	// Collection operations
+	GetCollections(ctx context.Context, search string, page, limit int) ([]models.CollectionWithStats, int64, error)
+	GetCollection(ctx context.Context, id uint) (*models.CollectionWithStats, error)
+	GetCollectionMovies(ctx context.Context, id uint, filter models.MovieFilter, projection models.MovieProjection) ([]models.Movie, int64, error)
+
+	// Tag and keyword operations
+	GetTags(ctx context.Context) ([]models.TagWithCount, error)
+	GetTag(ctx context.Context, id uint) (*models.TagWithCount, error)
+	CreateTag(ctx context.Context, name, description string) (*models.Tag, error)
+	UpdateTag(ctx context.Context, id uint, name, description string) (*models.Tag, error)
+	DeleteTag(ctx context.Context, id uint) error
+	TagMovie(ctx context.Context, movieID uint, tagIDs []uint) ([]models.Tag, error)
+	UntagMovie(ctx context.Context, movieID, tagID uint) error
+	GetTagCloud(ctx context.Context, cloudType string, limit int) ([]models.TagCloudEntry, error)
+
+	// Personal list operations
+	GetUserMovies(ctx context.Context, userID uint, list string, filter models.MovieFilter, projection models.MovieProjection) ([]models.Movie, int64, error)
+	AddUserMovie(ctx context.Context, userID, movieID uint, list string, at *time.Time) (*models.UserMovie, error)
+	RemoveUserMovie(ctx context.Context, userID, movieID uint, list string) (*models.UserMovie, error)
+	AnnotateUserMovies(ctx context.Context, userID uint, movies []models.Movie) error
+
+	// Rating and review operations
+	GetMovieReviews(ctx context.Context, movieID uint, page, limit int) ([]models.MovieReview, int64, error)
+	GetUserReviews(ctx context.Context, userID uint, page, limit int) ([]models.MovieReview, int64, error)
+	GetUserReview(ctx context.Context, userID, movieID uint) (*models.MovieReview, error)
+	ReviewMovie(ctx context.Context, userID, movieID uint, rating int, title, body string) (*models.MovieReview, error)
+	DeleteUserReview(ctx context.Context, userID, movieID uint) error
+	GetReviews(ctx context.Context, filter models.ReviewFilter) ([]models.MovieReview, int64, error)
+	ModerateReview(ctx context.Context, id, moderatorID uint, status, note string) (*models.MovieReview, error)
 
 	// Language operations
 	GetLanguageByCode(ctx context.Context, code string) (*models.Language, error)
 	CreateLanguage(ctx context.Context, code, name string) (*models.Language, error)
+	GetLanguages(ctx context.Context, locales []string) ([]models.LanguageWithCount, error)
+	GetLanguage(ctx context.Context, id uint, locales []string) (*models.LanguageWithCount, error)
+	UpdateLanguageName(ctx context.Context, id uint, name string) (*models.Language, error)
+	SetLanguageTranslation(ctx context.Context, id uint, locale, name string) (*models.LanguageTranslation, error)
+	DeleteLanguageTranslation(ctx context.Context, id uint, locale string) error
 }
 
 type movieService struct {
-	repo         repository.MovieRepository
-	genreRepo    repository.GenreRepository
-	langRepo     repository.LanguageRepository
-	config       *config.Config
-	logger       *logrus.Logger
-	httpClient   *http.Client
-	minioService *MinIOService
+	repo           repository.MovieRepository
+	genreRepo      repository.GenreRepository
+	langRepo       repository.LanguageRepository
+	collectionRepo repository.CollectionRepository
+	tagRepo        repository.TagRepository
+	providerRepo   repository.WatchProviderRepository
+	userMovieRepo  repository.UserMovieRepository
+	reviewRepo     repository.ReviewRepository
+	config         *config.Config
+	logger         *logrus.Logger
+	httpClient     *http.Client
+	minioService   *MinIOService
+
+	cache           Cache
+	cacheGroup      singleflight.Group
+	cacheGeneration atomic.Uint64
 }
 
-func NewMovieService(repo repository.MovieRepository, genreRepo repository.GenreRepository, langRepo repository.LanguageRepository, cfg *config.Config, logger *logrus.Logger) MovieService {
+func NewMovieService(repo repository.MovieRepository, genreRepo repository.GenreRepository, langRepo repository.LanguageRepository, collectionRepo repository.CollectionRepository, tagRepo repository.TagRepository, providerRepo repository.WatchProviderRepository, userMovieRepo repository.UserMovieRepository, reviewRepo repository.ReviewRepository, cfg *config.Config, logger *logrus.Logger) MovieService {
 	return &movieService{
-		repo:      repo,
-		genreRepo: genreRepo,
-		langRepo:  langRepo,
-		config:    cfg,
-		logger:    logger,
+		repo:           repo,
+		genreRepo:      genreRepo,
+		langRepo:       langRepo,
+		collectionRepo: collectionRepo,
+		tagRepo:        tagRepo,
+		providerRepo:   providerRepo,
+		userMovieRepo:  userMovieRepo,
+		reviewRepo:     reviewRepo,
+		config:         cfg,
+		logger:         logger,
 		httpClient: &http.Client{
 			Timeout: cfg.TMDB.HTTPTimeout,
 		},
+		cache: NewMemoryCache(cfg.Cache.MaxEntries),
 	}
 }
 
@@ -69,6 +162,11 @@ func (s *movieService) SetMinIOService(minioSvc *MinIOService) {
 	s.minioService = minioSvc
 }
 
+// SetCache replaces the default in-memory cache, e.g. with a Redis backend
+func (s *movieService) SetCache(cache Cache) {
+	s.cache = cache
+}
+
 func (s *movieService) CreateMovie(ctx context.Context, movie *models.Movie) error {
 	if movie.Title == "" {
 		return fmt.Errorf("movie title is required")
@@ -80,14 +178,31 @@ func (s *movieService) CreateMovie(ctx context.Context, movie *models.Movie) err
 		if err != nil {
 			return fmt.Errorf("failed to check existing movie: %w", err)
 		}
+		if existing != nil && existing.DeletedAt.Valid {
+			return fmt.Errorf("movie with TMDB ID %d is in the trash, restore it instead", movie.TMDBID)
+		}
 		if existing != nil {
 			return fmt.Errorf("movie with TMDB ID %d already exists", movie.TMDBID)
 		}
 	}
 
-	return s.repo.Create(ctx, movie)
+	err := s.repo.Transaction(ctx, func(repo repository.MovieRepository) error {
+		if err := repo.Create(ctx, movie); err != nil {
+			return err
+		}
+		return s.recordRevision(ctx, repo, movie.ID, models.RevisionActionCreate, nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidateCatalog(ctx)
+	return nil
 }
 
+// UpdateMovie replaces the editable fields of a movie. A non-zero
+// movie.Version is the version the client based its edit on; the update fails
+// with repository.ErrVersionConflict when the movie has moved on since.
 func (s *movieService) UpdateMovie(ctx context.Context, id uint, movie *models.Movie) error {
 	existing, err := s.repo.FindByID(ctx, id)
 	if err != nil {
@@ -96,54 +211,50 @@ func (s *movieService) UpdateMovie(ctx context.Context, id uint, movie *models.M
 	if existing == nil {
 		return fmt.Errorf("movie with ID %d not found", id)
 	}
+	if movie.Version != 0 && movie.Version != existing.Version {
+		return repository.ErrVersionConflict
+	}
 
-	// If image is being updated and old image is MinIO URL, delete it
-	if s.minioService != nil {
-		// Delete old poster if being replaced
-		if movie.PosterPath != "" && movie.PosterPath != existing.PosterPath {
-			if strings.Contains(existing.PosterPath, "http") && strings.Contains(existing.PosterPath, s.config.MinIO.BucketName) {
-				// Extract filename from URL
-				parts := strings.Split(existing.PosterPath, "/")
-				if len(parts) > 0 {
-					filename := parts[len(parts)-1]
-					// Remove query params if any (presigned URL)
-					if idx := strings.Index(filename, "?"); idx != -1 {
-						filename = filename[:idx]
-					}
-					if err := s.minioService.DeleteFile(filename); err != nil {
-						s.logger.WithError(err).Warn("Failed to delete old poster from MinIO")
-					}
-				}
-			}
+	movie.ID = id
+	movie.CreatedAt = existing.CreatedAt
+	movie.TMDBID = existing.TMDBID             // Don't allow changing TMDB ID
+	movie.CollectionID = existing.CollectionID // collections come from TMDB only
+	movie.Version = existing.Version
+
+	before := existing.AuditState()
+	edited := movie.AuditState()
+	edited.GenreIDs = before.GenreIDs // genres are not part of the update request
+	err = s.repo.Transaction(ctx, func(repo repository.MovieRepository) error {
+		if err := repo.Update(ctx, movie); err != nil {
+			return err
 		}
-
-		// Delete old backdrop if being replaced
-		if movie.BackdropPath != "" && movie.BackdropPath != existing.BackdropPath {
-			if strings.Contains(existing.BackdropPath, "http") && strings.Contains(existing.BackdropPath, s.config.MinIO.BucketName) {
-				// Extract filename from URL
-				parts := strings.Split(existing.BackdropPath, "/")
-				if len(parts) > 0 {
-					filename := parts[len(parts)-1]
-					// Remove query params if any (presigned URL)
-					if idx := strings.Index(filename, "?"); idx != -1 {
-						filename = filename[:idx]
-					}
-					if err := s.minioService.DeleteFile(filename); err != nil {
-						s.logger.WithError(err).Warn("Failed to delete old backdrop from MinIO")
-					}
-				}
-			}
+		if err := s.lockEditedFields(ctx, repo, id, &before, &edited); err != nil {
+			return err
 		}
+		return s.recordRevision(ctx, repo, id, models.RevisionActionUpdate, &before, nil)
+	})
+	if err != nil {
+		return err
 	}
 
-	movie.ID = id
-	movie.CreatedAt = existing.CreatedAt
-	movie.TMDBID = existing.TMDBID // Don't allow changing TMDB ID
+	// Replaced MinIO images are deleted only once the update committed, so
+	// a rejected edit leaves the live images in place
+	replaced := models.Movie{ID: id}
+	if movie.PosterPath != "" && movie.PosterPath != existing.PosterPath {
+		replaced.PosterPath = existing.PosterPath
+	}
+	if movie.BackdropPath != "" && movie.BackdropPath != existing.BackdropPath {
+		replaced.BackdropPath = existing.BackdropPath
+	}
+	s.deleteMovieImages(&replaced)
 
-	return s.repo.Update(ctx, movie)
+	s.invalidateCatalog(ctx)
+	return nil
 }
 
-func (s *movieService) DeleteMovie(ctx context.Context, id uint) error {
+// DeleteMovie moves a movie to the trash. A non-zero version must match the
+// stored version, as in UpdateMovie.
+func (s *movieService) DeleteMovie(ctx context.Context, id uint, version int64) error {
 	existing, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return err
@@ -151,65 +262,75 @@ func (s *movieService) DeleteMovie(ctx context.Context, id uint) error {
 	if existing == nil {
 		return fmt.Errorf("movie with ID %d not found", id)
 	}
+	if version != 0 && version != existing.Version {
+		return repository.ErrVersionConflict
+	}
 
-	// Delete images from MinIO if they are MinIO URLs
-	if s.minioService != nil {
-		// Delete poster
-		if existing.PosterPath != "" {
-			if strings.Contains(existing.PosterPath, "http") && strings.Contains(existing.PosterPath, s.config.MinIO.BucketName) {
-				// Extract filename from URL
-				parts := strings.Split(existing.PosterPath, "/")
-				if len(parts) > 0 {
-					filename := parts[len(parts)-1]
-					// Remove query params if any (presigned URL)
-					if idx := strings.Index(filename, "?"); idx != -1 {
-						filename = filename[:idx]
-					}
-					if err := s.minioService.DeleteFile(filename); err != nil {
-						s.logger.WithError(err).Warn("Failed to delete poster from MinIO")
-					}
-				}
-			}
-		}
-
-		// Delete backdrop
-		if existing.BackdropPath != "" {
-			if strings.Contains(existing.BackdropPath, "http") && strings.Contains(existing.BackdropPath, s.config.MinIO.BucketName) {
-				// Extract filename from URL
-				parts := strings.Split(existing.BackdropPath, "/")
-				if len(parts) > 0 {
-					filename := parts[len(parts)-1]
-					// Remove query params if any (presigned URL)
-					if idx := strings.Index(filename, "?"); idx != -1 {
-						filename = filename[:idx]
-					}
-					if err := s.minioService.DeleteFile(filename); err != nil {
-						s.logger.WithError(err).Warn("Failed to delete backdrop from MinIO")
-					}
-				}
-			}
+	// Images are kept until the movie is purged from the trash
+	before := existing.AuditState()
+	err = s.repo.Transaction(ctx, func(repo repository.MovieRepository) error {
+		if err := repo.Delete(ctx, id, existing.Version); err != nil {
+			return err
 		}
+		return s.recordRevision(ctx, repo, id, models.RevisionActionDelete, &before, nil)
+	})
+	if err != nil {
+		return err
 	}
 
-	return s.repo.Delete(ctx, id)
+	s.invalidateCatalog(ctx)
+	return nil
 }
 
-func (s *movieService) GetMovieByID(ctx context.Context, id uint) (*models.Movie, error) {
-	return s.repo.FindByID(ctx, id)
+// GetCatalogState returns the current catalog version used to validate
+// cached list, chart and dashboard responses
+func (s *movieService) GetCatalogState(ctx context.Context) (*models.CatalogState, error) {
+	return s.repo.GetCatalogState(ctx)
 }
 
-func (s *movieService) GetAllMovies(ctx context.Context, page, limit int, search, sortBy, order, startDate, endDate string) ([]models.Movie, int64, error) {
-	if page < 1 {
-		page = 1
+// invalidateCatalog bumps the catalog version and drops cached analytics
+// after movies have been written. Failures are logged only, since the write
+// itself already succeeded.
+func (s *movieService) invalidateCatalog(ctx context.Context) {
+	if err := s.repo.BumpCatalogVersion(ctx); err != nil {
+		s.logger.WithError(err).Warn("Failed to bump catalog version")
 	}
-	if limit < 1 {
-		limit = 20
+	s.invalidateAnalyticsCache(ctx)
+}
+
+func (s *movieService) GetMovieByID(ctx context.Context, id uint, projection models.MovieProjection) (*models.Movie, error) {
+	movie, err := s.repo.FindByIDWithProjection(ctx, id, projection)
+	if err != nil {
+		return nil, err
 	}
-	if limit > 100 {
-		limit = 100
+	movie.Localize(projection.Locales)
+	return movie, nil
+}
+
+func (s *movieService) GetAllMovies(ctx context.Context, filter models.MovieFilter, projection models.MovieProjection) ([]models.Movie, int64, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit < 1 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	movies, total, err := s.repo.FindAll(ctx, filter, projection)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range movies {
+		movies[i].Localize(projection.Locales)
 	}
+	return movies, total, nil
+}
 
-	return s.repo.FindAll(ctx, page, limit, search, sortBy, order, startDate, endDate)
+// ExportMovies streams every movie matching the filters to fn
+func (s *movieService) ExportMovies(ctx context.Context, filter models.MovieFilter, fn func(row *models.MovieExportRow) error) error {
+	return s.repo.StreamForExport(ctx, filter, fn)
 }
 
 func (s *movieService) SyncMoviesFromTMDB(ctx context.Context, pages int) (*models.SyncLog, error) {
@@ -228,6 +349,8 @@ func (s *movieService) SyncMoviesFromTMDB(ctx context.Context, pages int) (*mode
 	}
 
 	var moviesAdded, moviesUpdated int
+	ctx = audit.WithSource(ctx, audit.SourceSync)
+	collections := make(map[int]uint) // TMDB collection ID -> collection ID
 
 	for page := 1; page <= pages; page++ {
 		s.logger.WithField("page", page).Info("Fetching TMDB popular movies")
@@ -236,6 +359,7 @@ func (s *movieService) SyncMoviesFromTMDB(ctx context.Context, pages int) (*mode
 		if err != nil {
 			syncLog.ErrorMessage = fmt.Sprintf("failed to fetch page %d: %s", page, err.Error())
 			_ = s.repo.CreateSyncLog(ctx, syncLog)
+			s.invalidateCatalog(ctx)
 			return syncLog, err
 		}
 
@@ -277,6 +401,25 @@ func (s *movieService) SyncMoviesFromTMDB(ctx context.Context, pages int) (*mode
 			}
 			movie.Genres = genres
 
+			// A failed details request leaves everything it carries as
+			// earlier syncs stored it
+			details, detailsErr := s.fetchMovieDetails(ctx, movie.TMDBID)
+			if detailsErr != nil {
+				s.logger.WithError(detailsErr).WithField("tmdb_id", movie.TMDBID).Warn("Error fetching movie details")
+				details = &models.TMDBMovieDetailsResponse{}
+			}
+
+			translations, taglineSynced := s.syncMovieTranslations(movie, details.Translations)
+
+			// On failure an existing movie keeps its collection
+			collectionErr := detailsErr
+			if detailsErr == nil {
+				movie.CollectionID, collectionErr = s.syncMovieCollection(ctx, details.BelongsToCollection, collections)
+				if collectionErr != nil {
+					s.logger.WithError(collectionErr).WithField("tmdb_id", movie.TMDBID).Warn("Error syncing movie collection")
+				}
+			}
+
 			// Check if movie already exists
 			existing, err := s.repo.FindByTMDBID(ctx, movie.TMDBID)
 			if err != nil {
@@ -284,22 +427,109 @@ func (s *movieService) SyncMoviesFromTMDB(ctx context.Context, pages int) (*mode
 				continue
 			}
 
+			if existing != nil && existing.DeletedAt.Valid {
+				// Deleted movies stay in the trash until restored or purged
+				s.logger.WithField("tmdb_id", movie.TMDBID).Debug("Skipping trashed movie")
+				continue
+			}
+
 			if existing == nil {
 				// Create new movie
-				if err := s.repo.Create(ctx, movie); err != nil {
+				err := s.repo.Transaction(ctx, func(repo repository.MovieRepository) error {
+					if err := repo.Create(ctx, movie); err != nil {
+						return err
+					}
+					return s.recordRevision(ctx, repo, movie.ID, models.RevisionActionCreate, nil, nil)
+				})
+				if err != nil {
 					s.logger.WithError(err).WithField("title", movie.Title).Error("Error creating movie")
 					continue
 				}
 				moviesAdded++
 			} else {
+				// Update writes every column, so a movie whose details are
+				// missing would lose its tagline
+				if detailsErr != nil {
+					s.logger.WithField("tmdb_id", movie.TMDBID).Warn("Skipping update of movie without details")
+					continue
+				}
+
 				// Update existing movie
 				movie.ID = existing.ID
 				movie.CreatedAt = existing.CreatedAt
-				if err := s.repo.Update(ctx, movie); err != nil {
+				movie.Version = existing.Version
+				if !taglineSynced {
+					movie.Tagline = existing.Tagline
+				}
+				if collectionErr != nil {
+					movie.CollectionID = existing.CollectionID
+				}
+
+				locks, err := s.repo.FindFieldLocks(ctx, existing.ID)
+				if err != nil {
+					s.logger.WithError(err).WithField("tmdb_id", movie.TMDBID).Error("Error loading field locks")
+					continue
+				}
+				locked := make(map[string]bool, len(locks))
+				for _, lock := range locks {
+					locked[lock.Field] = true
+				}
+				skipped := movie.KeepLockedFields(existing, locked)
+
+				before := existing.AuditState()
+				err = s.repo.Transaction(ctx, func(repo repository.MovieRepository) error {
+					if err := repo.Update(ctx, movie); err != nil {
+						return err
+					}
+					return s.recordRevision(ctx, repo, movie.ID, models.RevisionActionUpdate, &before, nil)
+				})
+				if errors.Is(err, repository.ErrVersionConflict) {
+					// Edited since it was loaded; the next sync picks up the new state
+					s.logger.WithField("tmdb_id", movie.TMDBID).Warn("Skipping movie modified during sync")
+					syncLog.Conflicts++
+					continue
+				}
+				if err != nil {
 					s.logger.WithError(err).WithField("title", movie.Title).Error("Error updating movie")
 					continue
 				}
 				moviesUpdated++
+
+				if len(skipped) > 0 {
+					syncLog.SkippedFields = append(syncLog.SkippedFields, models.SyncSkippedFields{
+						MovieID: movie.ID,
+						TMDBID:  movie.TMDBID,
+						Fields:  skipped,
+					})
+					syncLog.FieldsSkipped += len(skipped)
+				}
+			}
+
+			for i := range translations {
+				translations[i].MovieID = movie.ID
+			}
+			if err := s.repo.UpsertTranslations(ctx, translations); err != nil {
+				s.logger.WithError(err).WithField("tmdb_id", movie.TMDBID).Warn("Error saving movie translations")
+			}
+			if details.Videos != nil {
+				if err := s.repo.ReplaceVideos(ctx, movie.ID, videosFromTMDB(details.Videos)); err != nil {
+					s.logger.WithError(err).WithField("tmdb_id", movie.TMDBID).Warn("Error saving movie videos")
+				}
+			}
+			if details.Keywords != nil {
+				if err := s.tagRepo.ReplaceMovieKeywords(ctx, movie.ID, keywordsFromTMDB(details.Keywords)); err != nil {
+					s.logger.WithError(err).WithField("tmdb_id", movie.TMDBID).Warn("Error saving movie keywords")
+				}
+			}
+			if details.ReleaseDates != nil {
+				if err := s.repo.ReplaceReleaseDates(ctx, movie.ID, releaseDatesFromTMDB(details.ReleaseDates)); err != nil {
+					s.logger.WithError(err).WithField("tmdb_id", movie.TMDBID).Warn("Error saving movie release dates")
+				}
+			}
+			if details.WatchProviders != nil {
+				if err := s.providerRepo.ReplaceMovieProviders(ctx, movie.ID, watchProvidersFromTMDB(details.WatchProviders)); err != nil {
+					s.logger.WithError(err).WithField("tmdb_id", movie.TMDBID).Warn("Error saving movie watch providers")
+				}
 			}
 		}
 	}
@@ -308,10 +538,13 @@ func (s *movieService) SyncMoviesFromTMDB(ctx context.Context, pages int) (*mode
 	syncLog.MoviesAdded = moviesAdded
 	syncLog.MoviesUpdated = moviesUpdated
 	_ = s.repo.CreateSyncLog(ctx, syncLog)
+	s.invalidateCatalog(ctx)
 
 	s.logger.WithFields(logrus.Fields{
 		"movies_added":   moviesAdded,
 		"movies_updated": moviesUpdated,
+		"fields_skipped": syncLog.FieldsSkipped,
+		"conflicts":      syncLog.Conflicts,
 	}).Info("Sync completed")
 
 	return syncLog, nil
@@ -348,6 +581,49 @@ func (s *movieService) fetchPopularMoviesFromTMDB(ctx context.Context, page int)
 	return tmdbResponse.Results, nil
 }
 
+// fetchMovieDetails fetches the details of a movie together with its
+// translations, videos, keywords, release dates and watch providers, in one
+// request instead of one per kind
+func (s *movieService) fetchMovieDetails(ctx context.Context, tmdbID int) (*models.TMDBMovieDetailsResponse, error) {
+	var details models.TMDBMovieDetailsResponse
+	path := fmt.Sprintf("/movie/%d?append_to_response=%s", tmdbID, models.TMDBMovieDetailsAppend)
+	if err := s.fetchTMDB(ctx, path, &details); err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
+
+// fetchTMDB GETs a TMDB API path such as "/collection/2344" and decodes the
+// JSON response into out. The API key is added to the query string.
+func (s *movieService) fetchTMDB(ctx context.Context, path string, out interface{}) error {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	url := fmt.Sprintf("%s%s%sapi_key=%s", s.config.TMDB.BaseURL, path, separator, s.config.TMDB.APIKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch from TMDB: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("TMDB API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode TMDB response: %w", err)
+	}
+	return nil
+}
+
 // getGenreName returns the genre name for a given TMDB genre ID
 func (s *movieService) getGenreName(genreID int) string {
 	genreMap := map[int]string{
@@ -378,22 +654,50 @@ func (s *movieService) getLanguageName(langCode string) string {
 	return langCode
 }
 
-func (s *movieService) GetDashboardStats(ctx context.Context) (*models.DashboardStats, error) {
-	return s.repo.GetDashboardStats(ctx)
+// GetDashboardStats returns the dashboard statistics. The provider coverage
+// breakdown is limited to region when one is given.
+func (s *movieService) GetDashboardStats(ctx context.Context, region string) (*models.DashboardStats, error) {
+	return cachedAnalytics(ctx, s, "dashboard:"+region, func(ctx context.Context) (*models.DashboardStats, error) {
+		return s.loadDashboardStats(ctx, region)
+	})
+}
+
+func (s *movieService) loadDashboardStats(ctx context.Context, region string) (*models.DashboardStats, error) {
+	stats, err := s.repo.GetDashboardStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.ProviderCoverage, err = s.providerRepo.FindAllWithCoverage(ctx, region, dashboardProviderLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider coverage: %w", err)
+	}
+	return stats, nil
 }
 
 func (s *movieService) GetLastSyncLog(ctx context.Context) (*models.SyncLog, error) {
 	return s.repo.GetLastSyncLog(ctx)
 }
 
-// GetChartData returns combined chart data for visualization
-func (s *movieService) GetChartData(ctx context.Context, startDate, endDate string) (*models.ChartDataResponse, error) {
+// GetChartData returns combined chart data for visualization. With a region
+// the years come from the release dates in that country.
+func (s *movieService) GetChartData(ctx context.Context, startDate, endDate, region string) (*models.ChartDataResponse, error) {
+	key, err := chartCacheKey("combined", startDate, endDate, region)
+	if err != nil {
+		return nil, err
+	}
+	return cachedAnalytics(ctx, s, key, func(ctx context.Context) (*models.ChartDataResponse, error) {
+		return s.loadChartData(ctx, startDate, endDate, region)
+	})
+}
+
+func (s *movieService) loadChartData(ctx context.Context, startDate, endDate, region string) (*models.ChartDataResponse, error) {
 	pieData, err := s.repo.GetMoviesByLanguage(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pie chart data: %w", err)
 	}
 
-	columnData, err := s.repo.GetMoviesByYear(ctx, startDate, endDate)
+	columnData, err := s.repo.GetMoviesByYear(ctx, startDate, endDate, region)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get column chart data: %w", err)
 	}
@@ -406,20 +710,31 @@ func (s *movieService) GetChartData(ctx context.Context, startDate, endDate stri
 
 // GetMoviesByLanguage returns movie distribution by language
 func (s *movieService) GetMoviesByLanguage(ctx context.Context) ([]models.PieChartData, error) {
-	return s.repo.GetMoviesByLanguage(ctx)
+	return cachedAnalytics(ctx, s, "charts:pie", s.repo.GetMoviesByLanguage)
 }
 
-// GetMoviesByYear returns movie distribution by year
-func (s *movieService) GetMoviesByYear(ctx context.Context, startDate, endDate string) ([]models.ColumnChartData, error) {
-	return s.repo.GetMoviesByYear(ctx, startDate, endDate)
+// GetMoviesByYear returns movie distribution by year, optionally by the
+// release dates in one country
+func (s *movieService) GetMoviesByYear(ctx context.Context, startDate, endDate, region string) ([]models.ColumnChartData, error) {
+	key, err := chartCacheKey("column", startDate, endDate, region)
+	if err != nil {
+		return nil, err
+	}
+	return cachedAnalytics(ctx, s, key, func(ctx context.Context) ([]models.ColumnChartData, error) {
+		return s.repo.GetMoviesByYear(ctx, startDate, endDate, region)
+	})
 }
 
-// GetMoviesByMonth returns movie distribution by month for a specific year
-func (s *movieService) GetMoviesByMonth(ctx context.Context, year int) ([]models.ColumnChartData, error) {
+// GetMoviesByMonth returns movie distribution by month for a specific year,
+// optionally by the release dates in one country
+func (s *movieService) GetMoviesByMonth(ctx context.Context, year int, region string) ([]models.ColumnChartData, error) {
 	if year < 1900 || year > 2100 {
 		return nil, fmt.Errorf("invalid year: %d", year)
 	}
-	return s.repo.GetMoviesByMonth(ctx, year)
+	key := fmt.Sprintf("charts:monthly:%d:%s", year, region)
+	return cachedAnalytics(ctx, s, key, func(ctx context.Context) ([]models.ColumnChartData, error) {
+		return s.repo.GetMoviesByMonth(ctx, year, region)
+	})
 }
 
 // GetLanguageByCode returns language by code
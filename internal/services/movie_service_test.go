@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"movie-backend/internal/config"
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+)
+
+// updateMovieRepository holds a single movie. Update fails with a version
+// conflict when conflict is set, like a concurrent write would.
+type updateMovieRepository struct {
+	repository.MovieRepository
+	movie    models.Movie
+	conflict bool
+}
+
+func (r *updateMovieRepository) FindByID(_ context.Context, _ uint) (*models.Movie, error) {
+	movie := r.movie
+	return &movie, nil
+}
+
+func (r *updateMovieRepository) Transaction(_ context.Context, fn func(repo repository.MovieRepository) error) error {
+	return fn(r)
+}
+
+func (r *updateMovieRepository) Update(_ context.Context, movie *models.Movie) error {
+	if r.conflict {
+		return repository.ErrVersionConflict
+	}
+	movie.Version++
+	r.movie = *movie
+	return nil
+}
+
+func (r *updateMovieRepository) LockFields(context.Context, uint, []string, string) error {
+	return nil
+}
+
+func (r *updateMovieRepository) CreateRevision(context.Context, *models.MovieRevision) error {
+	return nil
+}
+
+func (r *updateMovieRepository) BumpCatalogVersion(context.Context) error {
+	return nil
+}
+
+func TestUpdateMovieDeletesReplacedImagesAfterCommit(t *testing.T) {
+	tests := []struct {
+		name        string
+		conflict    bool
+		update      models.Movie
+		wantErr     error
+		wantDeleted []string
+	}{
+		{
+			name:        "replaced images are deleted",
+			update:      models.Movie{Title: "Edited", PosterPath: imageURL("new-poster.jpg"), BackdropPath: imageURL("new-backdrop.jpg")},
+			wantDeleted: []string{"backdrop.jpg", "poster.jpg"},
+		},
+		{
+			name:        "kept images are not deleted",
+			update:      models.Movie{Title: "Edited", PosterPath: imageURL("new-poster.jpg")},
+			wantDeleted: []string{"poster.jpg"},
+		},
+		{
+			name:     "rejected edit keeps the live images",
+			conflict: true,
+			update:   models.Movie{Title: "Edited", PosterPath: imageURL("new-poster.jpg"), BackdropPath: imageURL("new-backdrop.jpg")},
+			wantErr:  repository.ErrVersionConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minioService, recorder := newTestMinIO(t)
+			repo := &updateMovieRepository{
+				movie:    models.Movie{ID: 1, Title: "Original", PosterPath: imageURL("poster.jpg"), BackdropPath: imageURL("backdrop.jpg"), Version: 1},
+				conflict: tt.conflict,
+			}
+			service := &movieService{
+				repo:         repo,
+				config:       &config.Config{MinIO: config.MinIOConfig{BucketName: testBucket}},
+				logger:       discardLogger(),
+				minioService: minioService,
+				cache:        NewMemoryCache(10),
+			}
+
+			update := tt.update
+			err := service.UpdateMovie(context.Background(), 1, &update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateMovie returned %v, want %v", err, tt.wantErr)
+			}
+
+			if got, want := recorder.Deleted(), tt.wantDeleted; strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Errorf("deleted images %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFetchMovieDetailsAppendsEverythingInOneRequest(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path+"?"+r.URL.Query().Get("append_to_response"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{
+			"id": 603,
+			"belongs_to_collection": {"id": 2344, "name": "The Matrix Collection"},
+			"translations": {"translations": [{"iso_639_1": "id", "iso_3166_1": "ID", "data": {"title": "Matriks"}}]},
+			"videos": {"results": [{"id": "v1", "key": "abc", "site": "YouTube", "type": "Trailer", "official": true, "published_at": "2021-09-09T16:00:00.000Z"}]},
+			"keywords": {"keywords": [{"id": 310, "name": "artificial intelligence"}]},
+			"release_dates": {"results": [{"iso_3166_1": "us", "release_dates": [{"certification": "R ", "release_date": "1999-03-31T00:00:00.000Z", "type": 3}]}]},
+			"watch/providers": {"results": {"US": {"link": "https://example.com", "flatrate": [{"provider_id": 8, "provider_name": "Netflix"}]}}}
+		}`)
+	}))
+	defer server.Close()
+
+	service := &movieService{
+		config:     &config.Config{TMDB: config.TMDBConfig{BaseURL: server.URL, APIKey: "key"}},
+		logger:     discardLogger(),
+		httpClient: server.Client(),
+	}
+	details, err := service.fetchMovieDetails(context.Background(), 603)
+	if err != nil {
+		t.Fatalf("fetchMovieDetails: %v", err)
+	}
+
+	if want := "/movie/603?" + models.TMDBMovieDetailsAppend; len(requests) != 1 || requests[0] != want {
+		t.Errorf("got requests %v, want one request %q", requests, want)
+	}
+	if details.BelongsToCollection == nil || details.BelongsToCollection.ID != 2344 {
+		t.Errorf("got collection %+v, want 2344", details.BelongsToCollection)
+	}
+	if details.Translations == nil || len(details.Translations.Translations) != 1 {
+		t.Errorf("got translations %+v, want one", details.Translations)
+	}
+	if videos := videosFromTMDB(details.Videos); len(videos) != 1 || videos[0].Key != "abc" || videos[0].PublishedAt == nil {
+		t.Errorf("got videos %+v, want the trailer", videos)
+	}
+	if keywords := keywordsFromTMDB(details.Keywords); len(keywords) != 1 || keywords[0].TMDBID != 310 {
+		t.Errorf("got keywords %+v, want keyword 310", keywords)
+	}
+	releaseDates := releaseDatesFromTMDB(details.ReleaseDates)
+	if len(releaseDates) != 1 || releaseDates[0].Country != "US" || releaseDates[0].Certification != "R" || releaseDates[0].ReleaseDate != "1999-03-31" {
+		t.Errorf("got release dates %+v, want the US theatrical release", releaseDates)
+	}
+	providers := watchProvidersFromTMDB(details.WatchProviders)
+	if len(providers) != 1 || providers[0].Region != "US" || providers[0].Provider.TMDBID != 8 {
+		t.Errorf("got watch providers %+v, want Netflix in the US", providers)
+	}
+}
+
+func TestFetchMovieDetailsLeavesMissingAppendsNil(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"id": 603}`)
+	}))
+	defer server.Close()
+
+	service := &movieService{
+		config:     &config.Config{TMDB: config.TMDBConfig{BaseURL: server.URL}},
+		logger:     discardLogger(),
+		httpClient: server.Client(),
+	}
+	details, err := service.fetchMovieDetails(context.Background(), 603)
+	if err != nil {
+		t.Fatalf("fetchMovieDetails: %v", err)
+	}
+	// Sync only replaces stored data when TMDB sent it
+	if details.Translations != nil || details.Videos != nil || details.Keywords != nil || details.ReleaseDates != nil || details.WatchProviders != nil {
+		t.Errorf("got appended responses %+v, want none", details)
+	}
+}
+
+// syncMovieRepository holds one synced movie and records its updates
+type syncMovieRepository struct {
+	updateMovieRepository
+	updates int
+}
+
+func (r *syncMovieRepository) FindByTMDBID(context.Context, int) (*models.Movie, error) {
+	movie := r.movie
+	return &movie, nil
+}
+
+func (r *syncMovieRepository) FindFieldLocks(context.Context, uint) ([]models.MovieFieldLock, error) {
+	return nil, nil
+}
+
+func (r *syncMovieRepository) Transaction(_ context.Context, fn func(repo repository.MovieRepository) error) error {
+	return fn(r)
+}
+
+func (r *syncMovieRepository) Update(ctx context.Context, movie *models.Movie) error {
+	r.updates++
+	return r.updateMovieRepository.Update(ctx, movie)
+}
+
+func (r *syncMovieRepository) UpsertTranslations(context.Context, []models.MovieTranslation) error {
+	return nil
+}
+
+func (r *syncMovieRepository) CreateSyncLog(context.Context, *models.SyncLog) error {
+	return nil
+}
+
+type syncLanguageRepository struct {
+	repository.LanguageRepository
+}
+
+func (syncLanguageRepository) FindOrCreate(_ context.Context, code, name string) (*models.Language, error) {
+	return &models.Language{ID: 1, Code: code, Name: name}, nil
+}
+
+func TestSyncKeepsTheTaglineWithoutAnEnglishTranslation(t *testing.T) {
+	tests := []struct {
+		name        string
+		locales     []string
+		details     string // empty for a failed details request
+		wantUpdates int
+		wantTagline string
+	}{
+		{
+			name:        "English translation replaces the tagline",
+			details:     `{"id": 603, "translations": {"translations": [{"iso_639_1": "en", "iso_3166_1": "US", "data": {"tagline": "Free your mind"}}]}}`,
+			wantUpdates: 1,
+			wantTagline: "Free your mind",
+		},
+		{
+			name:        "English translation without locales configured",
+			locales:     []string{},
+			details:     `{"id": 603, "translations": {"translations": [{"iso_639_1": "en", "iso_3166_1": "US", "data": {"tagline": "Free your mind"}}]}}`,
+			wantUpdates: 1,
+			wantTagline: "Free your mind",
+		},
+		{
+			name:        "other translations keep the tagline",
+			details:     `{"id": 603, "translations": {"translations": [{"iso_639_1": "id", "iso_3166_1": "ID", "data": {"tagline": "Bebaskan pikiranmu"}}]}}`,
+			wantUpdates: 1,
+			wantTagline: "Welcome to the Real World",
+		},
+		{
+			name:        "no translations keep the tagline",
+			details:     `{"id": 603}`,
+			wantUpdates: 1,
+			wantTagline: "Welcome to the Real World",
+		},
+		{
+			name:        "failed details request skips the update",
+			wantTagline: "Welcome to the Real World",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/movie/popular":
+					_, _ = io.WriteString(w, `{"page": 1, "results": [{"id": 603, "title": "The Matrix", "original_language": "en"}]}`)
+				case tt.details == "":
+					w.WriteHeader(http.StatusInternalServerError)
+				default:
+					_, _ = io.WriteString(w, tt.details)
+				}
+			}))
+			defer server.Close()
+
+			repo := &syncMovieRepository{updateMovieRepository: updateMovieRepository{
+				movie: models.Movie{ID: 1, TMDBID: 603, Title: "The Matrix", Tagline: "Welcome to the Real World", Version: 1},
+			}}
+			locales := []string{"id"}
+			if tt.locales != nil {
+				locales = tt.locales
+			}
+			service := &movieService{
+				repo:       repo,
+				langRepo:   syncLanguageRepository{},
+				config:     &config.Config{TMDB: config.TMDBConfig{BaseURL: server.URL, Locales: locales}},
+				logger:     discardLogger(),
+				httpClient: server.Client(),
+				cache:      NewMemoryCache(10),
+			}
+
+			if _, err := service.SyncMoviesFromTMDB(context.Background(), 1); err != nil {
+				t.Fatalf("SyncMoviesFromTMDB: %v", err)
+			}
+			if repo.updates != tt.wantUpdates {
+				t.Errorf("got %d updates, want %d", repo.updates, tt.wantUpdates)
+			}
+			if repo.movie.Tagline != tt.wantTagline {
+				t.Errorf("got tagline %q, want %q", repo.movie.Tagline, tt.wantTagline)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"strings"
+
+	"movie-backend/internal/models"
+)
+
+// syncMovieTranslations sets the English tagline on the movie from its TMDB
+// translations and returns the translations for the configured locales. It
+// reports whether TMDB sent an English translation; without one the movie's
+// tagline is left alone. A nil response, when the details had none, skips
+// the translations.
+func (s *movieService) syncMovieTranslations(movie *models.Movie, response *models.TMDBTranslationsResponse) ([]models.MovieTranslation, bool) {
+	if response == nil {
+		return nil, false
+	}
+
+	var translations []models.MovieTranslation
+	english := false
+	seen := make(map[string]bool)
+	for _, t := range response.Translations {
+		locale := strings.ToLower(t.ISO6391 + "-" + t.ISO31661)
+
+		if t.ISO6391 == "en" && (movie.Tagline == "" || t.ISO31661 == "US") {
+			movie.Tagline = t.Data.Tagline
+			english = true
+		}
+
+		if seen[locale] || !s.syncsLocale(t.ISO6391, locale) {
+			continue
+		}
+		if t.Data.Title == "" && t.Data.Overview == "" && t.Data.Tagline == "" {
+			continue
+		}
+		seen[locale] = true
+		translations = append(translations, models.MovieTranslation{
+			Locale:   locale,
+			Title:    t.Data.Title,
+			Overview: t.Data.Overview,
+			Tagline:  t.Data.Tagline,
+		})
+	}
+	return translations, english
+}
+
+// syncsLocale reports whether a TMDB translation matches a configured locale.
+// A configured language without a region, e.g. "id", matches every region.
+func (s *movieService) syncsLocale(language, locale string) bool {
+	for _, configured := range s.config.TMDB.Locales {
+		configured = strings.ToLower(strings.ReplaceAll(configured, "_", "-"))
+		if configured == locale || configured == language {
+			return true
+		}
+	}
+	return false
+}
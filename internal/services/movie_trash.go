@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// purgeBatchSize bounds the number of movies purged per query
+const purgeBatchSize = 100
+
+var ErrMovieNotInTrash = errors.New("movie not found in trash")
+
+// GetTrashedMovies lists soft-deleted movies with the regular list filters
+func (s *movieService) GetTrashedMovies(ctx context.Context, filter models.MovieFilter, projection models.MovieProjection) ([]models.Movie, int64, error) {
+	filter.Trashed = true
+	return s.GetAllMovies(ctx, filter, projection)
+}
+
+func (s *movieService) RestoreMovie(ctx context.Context, id uint) (*models.Movie, error) {
+	err := s.repo.Transaction(ctx, func(repo repository.MovieRepository) error {
+		restored, err := repo.Restore(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !restored {
+			return ErrMovieNotInTrash
+		}
+		return s.recordRevision(ctx, repo, id, models.RevisionActionRestore, nil, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateCatalog(ctx)
+	return s.repo.FindByID(ctx, id)
+}
+
+// PurgeTrash permanently deletes movies that have been in the trash longer
+// than the retention window, together with their MinIO images
+func (s *movieService) PurgeTrash(ctx context.Context) (int, error) {
+	cutoff := time.Now().Add(-s.config.Server.TrashRetention)
+
+	purged := 0
+	for {
+		movies, err := s.repo.FindPurgeable(ctx, cutoff, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		for i := range movies {
+			// Use the images of the purged row, the listed one may be stale
+			movie, err := s.repo.Purge(ctx, movies[i].ID, cutoff)
+			if errors.Is(err, repository.ErrMovieNotPurgeable) {
+				continue
+			}
+			if err != nil {
+				return purged, err
+			}
+			s.deleteMovieImages(movie)
+			purged++
+		}
+
+		if len(movies) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// deleteMovieImages removes the poster and backdrop from MinIO if they are
+// MinIO URLs
+func (s *movieService) deleteMovieImages(movie *models.Movie) {
+	if s.minioService == nil {
+		return
+	}
+
+	for kind, path := range map[string]string{"poster": movie.PosterPath, "backdrop": movie.BackdropPath} {
+		if path == "" || !strings.Contains(path, "http") || !strings.Contains(path, s.config.MinIO.BucketName) {
+			continue
+		}
+
+		// Extract filename from URL, without query params (presigned URL)
+		parts := strings.Split(path, "/")
+		filename := parts[len(parts)-1]
+		if idx := strings.Index(filename, "?"); idx != -1 {
+			filename = filename[:idx]
+		}
+		if err := s.minioService.DeleteFile(filename); err != nil {
+			s.logger.WithError(err).WithField("movie_id", movie.ID).Warnf("Failed to delete %s from MinIO", kind)
+		}
+	}
+}
+
+// StartTrashPurge periodically purges expired movies from the trash until ctx
+// is cancelled
+func StartTrashPurge(ctx context.Context, service MovieService, interval time.Duration, logger *logrus.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := service.PurgeTrash(ctx)
+			if err != nil {
+				logger.WithError(err).Warn("Failed to purge movie trash")
+			}
+			if purged > 0 {
+				logger.WithField("purged", purged).Info("Expired movies purged from trash")
+			}
+		}
+	}
+}
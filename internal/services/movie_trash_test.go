@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"movie-backend/internal/config"
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/sirupsen/logrus"
+)
+
+const testBucket = "movies"
+
+// minioRecorder is a fake MinIO server that records deleted objects
+type minioRecorder struct {
+	mu      sync.Mutex
+	deleted []string
+}
+
+func (m *minioRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodDelete {
+		m.mu.Lock()
+		m.deleted = append(m.deleted, strings.TrimPrefix(r.URL.Path, "/"+testBucket+"/"))
+		m.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *minioRecorder) Deleted() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	deleted := append([]string(nil), m.deleted...)
+	sort.Strings(deleted)
+	return deleted
+}
+
+// newTestMinIO returns a MinIOService backed by a minioRecorder
+func newTestMinIO(t *testing.T) (*MinIOService, *minioRecorder) {
+	t.Helper()
+	recorder := &minioRecorder{}
+	server := httptest.NewServer(recorder)
+	t.Cleanup(server.Close)
+
+	client, err := minio.New(strings.TrimPrefix(server.URL, "http://"), &minio.Options{
+		Creds:  credentials.NewStaticV4("access", "secret", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatalf("minio.New: %v", err)
+	}
+	return &MinIOService{client: client, bucket: testBucket, logger: discardLogger()}, recorder
+}
+
+func discardLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+func imageURL(name string) string {
+	return "http://minio.local/" + testBucket + "/" + name
+}
+
+// purgeMovieRepository lists the trashed movies once and purges those in
+// purgeable; the rest were restored in the meantime
+type purgeMovieRepository struct {
+	repository.MovieRepository
+	trashed   []models.Movie
+	purgeable map[uint]models.Movie
+	cutoffs   []time.Time
+	listed    bool
+}
+
+func (r *purgeMovieRepository) FindPurgeable(_ context.Context, _ time.Time, _ int) ([]models.Movie, error) {
+	if r.listed {
+		return nil, nil
+	}
+	r.listed = true
+	return r.trashed, nil
+}
+
+func (r *purgeMovieRepository) Purge(_ context.Context, id uint, deletedBefore time.Time) (*models.Movie, error) {
+	r.cutoffs = append(r.cutoffs, deletedBefore)
+	movie, ok := r.purgeable[id]
+	if !ok {
+		return nil, repository.ErrMovieNotPurgeable
+	}
+	return &movie, nil
+}
+
+func TestPurgeTrashSkipsRestoredMovies(t *testing.T) {
+	minioService, recorder := newTestMinIO(t)
+	repo := &purgeMovieRepository{
+		trashed: []models.Movie{
+			{ID: 1, PosterPath: imageURL("old-poster-1.jpg")},
+			{ID: 2, PosterPath: imageURL("poster-2.jpg"), BackdropPath: imageURL("backdrop-2.jpg")},
+		},
+		// Movie 1 got a new poster before it was deleted again, movie 2 was
+		// restored while the purge ran
+		purgeable: map[uint]models.Movie{
+			1: {ID: 1, PosterPath: imageURL("poster-1.jpg")},
+		},
+	}
+	cfg := &config.Config{Server: config.ServerConfig{TrashRetention: 24 * time.Hour}, MinIO: config.MinIOConfig{BucketName: testBucket}}
+	service := &movieService{repo: repo, config: cfg, logger: discardLogger(), minioService: minioService}
+
+	before := time.Now()
+	purged, err := service.PurgeTrash(context.Background())
+	if err != nil {
+		t.Fatalf("PurgeTrash: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("purged %d movies, want 1", purged)
+	}
+
+	if got, want := recorder.Deleted(), []string{"poster-1.jpg"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("deleted images %v, want %v", got, want)
+	}
+
+	after := time.Now()
+	for _, cutoff := range repo.cutoffs {
+		if cutoff.Before(before.Add(-cfg.Server.TrashRetention)) || cutoff.After(after.Add(-cfg.Server.TrashRetention)) {
+			t.Errorf("Purge got cutoff %v, want now minus the retention", cutoff)
+		}
+	}
+}
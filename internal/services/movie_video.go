@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"movie-backend/internal/models"
+)
+
+// GetMovieVideos returns the videos of a movie, optionally only the given types
+func (s *movieService) GetMovieVideos(ctx context.Context, id uint, types []string) ([]models.MovieVideo, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.FindVideos(ctx, id, types)
+}
+
+// videosFromTMDB converts the TMDB videos of a movie, in every language
+func videosFromTMDB(response *models.TMDBVideosResponse) []models.MovieVideo {
+	videos := make([]models.MovieVideo, 0, len(response.Results))
+	for _, v := range response.Results {
+		video := models.MovieVideo{
+			TMDBID:   v.ID,
+			Name:     v.Name,
+			Site:     v.Site,
+			Key:      v.Key,
+			Type:     v.Type,
+			Official: v.Official,
+			Size:     v.Size,
+			Language: v.ISO6391,
+			Region:   v.ISO31661,
+		}
+		if publishedAt, err := time.Parse(time.RFC3339, v.PublishedAt); err == nil {
+			video.PublishedAt = &publishedAt
+		}
+		videos = append(videos, video)
+	}
+	return videos
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"movie-backend/internal/config"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// RateLimitResult is the state of a client's counter after a request
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	ResetAt   time.Time // end of the current window
+}
+
+// RateLimiter counts requests per key in fixed windows. Implementations must
+// be safe for concurrent use.
+type RateLimiter interface {
+	// Allow counts a request for key and reports whether it is within limit
+	// requests per window
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error)
+	// Peek returns the counter of key in the current window without counting
+	// a request
+	Peek(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error)
+}
+
+// NewRateLimiter builds the rate limit backend selected in the configuration.
+// The redis backend uses the cache's Redis connection settings.
+func NewRateLimiter(cfg *config.RateLimitConfig, cacheCfg *config.CacheConfig, logger *logrus.Logger) (RateLimiter, error) {
+	switch strings.ToLower(cfg.Backend) {
+	case "", "memory":
+		return NewMemoryRateLimiter(), nil
+	case "redis":
+		client := redis.NewClient(&redis.Options{
+			Addr:     cacheCfg.RedisAddr,
+			Password: cacheCfg.RedisPassword,
+			DB:       cacheCfg.RedisDB,
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := client.Ping(ctx).Err(); err != nil {
+			return nil, fmt.Errorf("failed to connect to redis at %s: %w", cacheCfg.RedisAddr, err)
+		}
+
+		logger.WithField("addr", cacheCfg.RedisAddr).Info("Redis rate limiter initialized successfully")
+		return NewRedisRateLimiter(client, cacheCfg.KeyPrefix), nil
+	}
+	return nil, fmt.Errorf("unknown rate limit backend %q", cfg.Backend)
+}
+
+// windowStart returns the start of the fixed window containing now
+func windowStart(now time.Time, window time.Duration) time.Time {
+	return now.Truncate(window)
+}
+
+func rateLimitResult(count int64, limit int, resetAt time.Time) RateLimitResult {
+	remaining := limit - int(count)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return RateLimitResult{
+		Allowed:   count <= int64(limit),
+		Limit:     limit,
+		Remaining: remaining,
+		ResetAt:   resetAt,
+	}
+}
+
+// MemoryRateLimiter keeps counters in process, so each replica enforces its
+// own limits
+type MemoryRateLimiter struct {
+	mu        sync.Mutex
+	counters  map[string]*memoryRateCounter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type memoryRateCounter struct {
+	count   int64
+	resetAt time.Time
+}
+
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{counters: make(map[string]*memoryRateCounter), now: time.Now}
+}
+
+func (l *MemoryRateLimiter) Allow(_ context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) > time.Minute {
+		for k, counter := range l.counters {
+			if !now.Before(counter.resetAt) {
+				delete(l.counters, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	counter, ok := l.counters[key]
+	if !ok || !now.Before(counter.resetAt) {
+		counter = &memoryRateCounter{resetAt: windowStart(now, window).Add(window)}
+		l.counters[key] = counter
+	}
+	counter.count++
+	return rateLimitResult(counter.count, limit, counter.resetAt), nil
+}
+
+func (l *MemoryRateLimiter) Peek(_ context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	counter, ok := l.counters[key]
+	if !ok || !now.Before(counter.resetAt) {
+		return rateLimitResult(0, limit, windowStart(now, window).Add(window)), nil
+	}
+	return rateLimitResult(counter.count, limit, counter.resetAt), nil
+}
+
+// RedisPipeliner is the subset of the go-redis API used by RedisRateLimiter
+type RedisPipeliner interface {
+	TxPipelined(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error)
+}
+
+// RedisRateLimiter shares counters between replicas through Redis. Each
+// window has its own key, which expires with the window.
+type RedisRateLimiter struct {
+	client RedisPipeliner
+	prefix string
+}
+
+func NewRedisRateLimiter(client RedisPipeliner, keyPrefix string) *RedisRateLimiter {
+	return &RedisRateLimiter{client: client, prefix: keyPrefix}
+}
+
+// windowKey returns the Redis key of key's counter in the window containing
+// now, and the end of that window
+func (l *RedisRateLimiter) windowKey(key string, window time.Duration) (string, time.Time) {
+	start := windowStart(time.Now(), window)
+	return l.prefix + "ratelimit:" + key + ":" + strconv.FormatInt(start.Unix(), 10), start.Add(window)
+}
+
+func (l *RedisRateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	redisKey, resetAt := l.windowKey(key, window)
+
+	var incr *redis.IntCmd
+	_, err := l.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, redisKey)
+		pipe.ExpireAt(ctx, redisKey, resetAt.Add(time.Second))
+		return nil
+	})
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+	return rateLimitResult(incr.Val(), limit, resetAt), nil
+}
+
+func (l *RedisRateLimiter) Peek(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	redisKey, resetAt := l.windowKey(key, window)
+
+	var get *redis.StringCmd
+	_, err := l.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, redisKey)
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return rateLimitResult(0, limit, resetAt), nil
+	}
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+	count, err := get.Int64()
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+	return rateLimitResult(count, limit, resetAt), nil
+}
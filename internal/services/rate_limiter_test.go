@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryRateLimiterAllow(t *testing.T) {
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	type step struct {
+		at            time.Duration // since start
+		key           string
+		wantAllowed   bool
+		wantRemaining int
+		wantResetAt   time.Duration // since start
+	}
+	tests := []struct {
+		name   string
+		limit  int
+		window time.Duration
+		steps  []step
+	}{
+		{
+			name:  "counts down to the limit",
+			limit: 2, window: time.Minute,
+			steps: []step{
+				{at: 0, key: "a", wantAllowed: true, wantRemaining: 1, wantResetAt: time.Minute},
+				{at: 10 * time.Second, key: "a", wantAllowed: true, wantRemaining: 0, wantResetAt: time.Minute},
+				{at: 20 * time.Second, key: "a", wantAllowed: false, wantRemaining: 0, wantResetAt: time.Minute},
+				{at: 30 * time.Second, key: "a", wantAllowed: false, wantRemaining: 0, wantResetAt: time.Minute},
+			},
+		},
+		{
+			name:  "keys are counted separately",
+			limit: 1, window: time.Minute,
+			steps: []step{
+				{at: 0, key: "a", wantAllowed: true, wantRemaining: 0, wantResetAt: time.Minute},
+				{at: 0, key: "b", wantAllowed: true, wantRemaining: 0, wantResetAt: time.Minute},
+				{at: time.Second, key: "a", wantAllowed: false, wantRemaining: 0, wantResetAt: time.Minute},
+			},
+		},
+		{
+			name:  "a new window starts over",
+			limit: 1, window: time.Minute,
+			steps: []step{
+				{at: 59 * time.Second, key: "a", wantAllowed: true, wantRemaining: 0, wantResetAt: time.Minute},
+				{at: 59*time.Second + 999*time.Millisecond, key: "a", wantAllowed: false, wantRemaining: 0, wantResetAt: time.Minute},
+				{at: time.Minute, key: "a", wantAllowed: true, wantRemaining: 0, wantResetAt: 2 * time.Minute},
+			},
+		},
+		{
+			name:  "windows are aligned to the clock",
+			limit: 5, window: time.Hour,
+			steps: []step{
+				{at: 90 * time.Minute, key: "a", wantAllowed: true, wantRemaining: 4, wantResetAt: 2 * time.Hour},
+				{at: 119 * time.Minute, key: "a", wantAllowed: true, wantRemaining: 3, wantResetAt: 2 * time.Hour},
+				{at: 2 * time.Hour, key: "a", wantAllowed: true, wantRemaining: 4, wantResetAt: 3 * time.Hour},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewMemoryRateLimiter()
+			for i, s := range tt.steps {
+				limiter.now = func() time.Time { return start.Add(s.at) }
+
+				result, err := limiter.Allow(context.Background(), s.key, tt.limit, tt.window)
+				if err != nil {
+					t.Fatalf("step %d: Allow: %v", i, err)
+				}
+				if result.Allowed != s.wantAllowed || result.Remaining != s.wantRemaining || result.Limit != tt.limit {
+					t.Errorf("step %d: got allowed=%t remaining=%d limit=%d, want allowed=%t remaining=%d limit=%d",
+						i, result.Allowed, result.Remaining, result.Limit, s.wantAllowed, s.wantRemaining, tt.limit)
+				}
+				if want := start.Add(s.wantResetAt); !result.ResetAt.Equal(want) {
+					t.Errorf("step %d: got reset at %v, want %v", i, result.ResetAt, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryRateLimiterSweepsExpiredCounters(t *testing.T) {
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	limiter := NewMemoryRateLimiter()
+
+	limiter.now = func() time.Time { return start }
+	if _, err := limiter.Allow(context.Background(), "a", 1, time.Minute); err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+
+	limiter.now = func() time.Time { return start.Add(5 * time.Minute) }
+	if _, err := limiter.Allow(context.Background(), "b", 1, time.Minute); err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+	if _, ok := limiter.counters["a"]; ok {
+		t.Error("expired counter was not swept")
+	}
+}
+
+func TestMemoryRateLimiterPeek(t *testing.T) {
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	limiter := NewMemoryRateLimiter()
+	limiter.now = func() time.Time { return start.Add(10 * time.Second) }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		result, err := limiter.Peek(ctx, "a", 2, time.Minute)
+		if err != nil {
+			t.Fatalf("Peek: %v", err)
+		}
+		if want := 2 - i; result.Remaining != max(want, 0) {
+			t.Errorf("after %d requests: got remaining %d, want %d", i, result.Remaining, max(want, 0))
+		}
+		if !result.ResetAt.Equal(start.Add(time.Minute)) {
+			t.Errorf("after %d requests: got reset at %v, want %v", i, result.ResetAt, start.Add(time.Minute))
+		}
+		if _, err := limiter.Allow(ctx, "a", 2, time.Minute); err != nil {
+			t.Fatalf("Allow: %v", err)
+		}
+	}
+
+	// Peeking doesn't count
+	result, _ := limiter.Peek(ctx, "a", 5, time.Minute)
+	if result.Remaining != 2 {
+		t.Errorf("got remaining %d after 3 requests with a limit of 5, want 2", result.Remaining)
+	}
+
+	limiter.now = func() time.Time { return start.Add(time.Minute) }
+	result, _ = limiter.Peek(ctx, "a", 2, time.Minute)
+	if result.Remaining != 2 || !result.ResetAt.Equal(start.Add(2*time.Minute)) {
+		t.Errorf("in the next window: got remaining %d reset at %v, want 2 at %v", result.Remaining, result.ResetAt, start.Add(2*time.Minute))
+	}
+}
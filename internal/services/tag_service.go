@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+)
+
+var (
+	ErrTagNotFound         = errors.New("tag not found")
+	ErrTagNameRequired     = errors.New("tag name is required")
+	ErrTagNameExists       = errors.New("a tag with this name already exists")
+	ErrTagIDsRequired      = errors.New("tag_ids is required")
+	ErrMovieTagNotFound    = errors.New("movie does not have this tag")
+	ErrInvalidTagCloudType = errors.New("type must be tag, keyword or all")
+)
+
+const (
+	defaultTagCloudLimit = 50
+	maxTagCloudLimit     = 200
+)
+
+func (s *movieService) GetTags(ctx context.Context) ([]models.TagWithCount, error) {
+	return s.tagRepo.FindAllWithMovieCount(ctx)
+}
+
+func (s *movieService) GetTag(ctx context.Context, id uint) (*models.TagWithCount, error) {
+	tag, err := s.tagRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+
+	count, err := s.tagRepo.CountMovies(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tag movies: %w", err)
+	}
+	return &models.TagWithCount{Tag: *tag, MovieCount: count}, nil
+}
+
+func (s *movieService) CreateTag(ctx context.Context, name, description string) (*models.Tag, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrTagNameRequired
+	}
+	if err := s.ensureTagNameAvailable(ctx, name, 0); err != nil {
+		return nil, err
+	}
+
+	tag := &models.Tag{Name: name, Description: strings.TrimSpace(description)}
+	if err := s.tagRepo.Create(ctx, tag); err != nil {
+		if errors.Is(err, repository.ErrTagNameTaken) {
+			return nil, ErrTagNameExists
+		}
+		return nil, err
+	}
+	return tag, nil
+}
+
+func (s *movieService) UpdateTag(ctx context.Context, id uint, name, description string) (*models.Tag, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrTagNameRequired
+	}
+
+	tag, err := s.tagRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+	if err := s.ensureTagNameAvailable(ctx, name, id); err != nil {
+		return nil, err
+	}
+
+	tag.Name = name
+	tag.Description = strings.TrimSpace(description)
+	if err := s.tagRepo.Update(ctx, tag); err != nil {
+		if errors.Is(err, repository.ErrTagNameTaken) {
+			return nil, ErrTagNameExists
+		}
+		return nil, err
+	}
+
+	s.invalidateCatalog(ctx)
+	return tag, nil
+}
+
+// DeleteTag removes the tag from all movies and deletes it
+func (s *movieService) DeleteTag(ctx context.Context, id uint) error {
+	tag, err := s.tagRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if tag == nil {
+		return ErrTagNotFound
+	}
+
+	if err := s.tagRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.invalidateCatalog(ctx)
+	return nil
+}
+
+// TagMovie adds tags to a movie and returns all of its tags
+func (s *movieService) TagMovie(ctx context.Context, movieID uint, tagIDs []uint) ([]models.Tag, error) {
+	if len(tagIDs) == 0 {
+		return nil, ErrTagIDsRequired
+	}
+	if _, err := s.repo.FindByID(ctx, movieID); err != nil {
+		return nil, err
+	}
+
+	tags, err := s.tagRepo.FindByIDs(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[uint]bool, len(tags))
+	for _, tag := range tags {
+		found[tag.ID] = true
+	}
+	for _, id := range tagIDs {
+		if !found[id] {
+			return nil, fmt.Errorf("%w: %d", ErrTagNotFound, id)
+		}
+	}
+
+	if err := s.tagRepo.AddToMovie(ctx, movieID, tags); err != nil {
+		return nil, err
+	}
+
+	s.invalidateCatalog(ctx)
+	return s.tagRepo.FindByMovie(ctx, movieID)
+}
+
+func (s *movieService) UntagMovie(ctx context.Context, movieID, tagID uint) error {
+	if _, err := s.repo.FindByID(ctx, movieID); err != nil {
+		return err
+	}
+
+	removed, err := s.tagRepo.RemoveFromMovie(ctx, movieID, tagID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrMovieTagNotFound
+	}
+
+	s.invalidateCatalog(ctx)
+	return nil
+}
+
+// GetTagCloud returns the most used tags and/or keywords. cloudType is "tag",
+// "keyword" or "all"; "all" merges both by movie count.
+func (s *movieService) GetTagCloud(ctx context.Context, cloudType string, limit int) ([]models.TagCloudEntry, error) {
+	if limit < 1 {
+		limit = defaultTagCloudLimit
+	}
+	if limit > maxTagCloudLimit {
+		limit = maxTagCloudLimit
+	}
+
+	var types []string
+	switch cloudType {
+	case models.TagCloudTypeTag, models.TagCloudTypeKeyword:
+		types = []string{cloudType}
+	case "", "all":
+		types = []string{models.TagCloudTypeTag, models.TagCloudTypeKeyword}
+	default:
+		return nil, ErrInvalidTagCloudType
+	}
+
+	entries := []models.TagCloudEntry{}
+	for _, t := range types {
+		found, err := s.tagRepo.Cloud(ctx, t, limit)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, found...)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].MovieCount != entries[j].MovieCount {
+			return entries[i].MovieCount > entries[j].MovieCount
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+func (s *movieService) ensureTagNameAvailable(ctx context.Context, name string, id uint) error {
+	existing, err := s.tagRepo.FindByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to check existing tag: %w", err)
+	}
+	if existing != nil && existing.ID != id {
+		return ErrTagNameExists
+	}
+	return nil
+}
+
+// keywordsFromTMDB converts the TMDB keywords of a movie
+func keywordsFromTMDB(response *models.TMDBKeywordsResponse) []models.Keyword {
+	keywords := make([]models.Keyword, 0, len(response.Keywords))
+	for _, k := range response.Keywords {
+		keywords = append(keywords, models.Keyword{TMDBID: k.ID, Name: k.Name})
+	}
+	return keywords
+}
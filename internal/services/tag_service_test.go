@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"movie-backend/internal/models"
+	"movie-backend/internal/repository"
+)
+
+// racingTagRepository finds no tag with the name, like a check that ran
+// before a concurrent request created it, and then hits the unique index
+type racingTagRepository struct {
+	repository.TagRepository
+}
+
+func (racingTagRepository) FindByID(_ context.Context, id uint) (*models.Tag, error) {
+	return &models.Tag{ID: id, Name: "Old"}, nil
+}
+
+func (racingTagRepository) FindByName(context.Context, string) (*models.Tag, error) {
+	return nil, nil
+}
+
+func (racingTagRepository) Create(context.Context, *models.Tag) error {
+	return repository.ErrTagNameTaken
+}
+
+func (racingTagRepository) Update(context.Context, *models.Tag) error {
+	return repository.ErrTagNameTaken
+}
+
+func TestTagNameRaceIsAConflict(t *testing.T) {
+	service := &movieService{tagRepo: racingTagRepository{}, logger: discardLogger()}
+
+	if _, err := service.CreateTag(context.Background(), "Staff Picks", ""); !errors.Is(err, ErrTagNameExists) {
+		t.Errorf("CreateTag returned %v, want %v", err, ErrTagNameExists)
+	}
+	if _, err := service.UpdateTag(context.Background(), 1, "staff picks", ""); !errors.Is(err, ErrTagNameExists) {
+		t.Errorf("UpdateTag returned %v, want %v", err, ErrTagNameExists)
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"movie-backend/internal/models"
+)
+
+var (
+	ErrUnknownUserList = errors.New("list must be watchlist, favorites or watched")
+	ErrWatchedInFuture = errors.New("watched_at cannot be in the future")
+)
+
+func validUserList(list string) bool {
+	switch list {
+	case models.UserListWatchlist, models.UserListFavorites, models.UserListWatched:
+		return true
+	}
+	return false
+}
+
+// GetUserMovies lists the movies on one of the user's lists, with the same
+// filters, sorting and projection as the catalog list
+func (s *movieService) GetUserMovies(ctx context.Context, userID uint, list string, filter models.MovieFilter, projection models.MovieProjection) ([]models.Movie, int64, error) {
+	if !validUserList(list) {
+		return nil, 0, ErrUnknownUserList
+	}
+	filter.UserID = userID
+	filter.UserList = list
+
+	movies, total, err := s.GetAllMovies(ctx, filter, projection)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.AnnotateUserMovies(ctx, userID, movies); err != nil {
+		return nil, 0, err
+	}
+	return movies, total, nil
+}
+
+// AddUserMovie puts a live movie on one of the user's lists. at is when it
+// was watched for the watched list and defaults to now.
+func (s *movieService) AddUserMovie(ctx context.Context, userID, movieID uint, list string, at *time.Time) (*models.UserMovie, error) {
+	if !validUserList(list) {
+		return nil, ErrUnknownUserList
+	}
+	if _, err := s.repo.FindByID(ctx, movieID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if at == nil || list != models.UserListWatched {
+		at = &now
+	} else if at.After(now) {
+		return nil, ErrWatchedInFuture
+	}
+	return s.userMovieRepo.SetList(ctx, userID, movieID, list, at)
+}
+
+// RemoveUserMovie takes a movie off one of the user's lists. Removing a
+// movie that is not on the list succeeds.
+func (s *movieService) RemoveUserMovie(ctx context.Context, userID, movieID uint, list string) (*models.UserMovie, error) {
+	if !validUserList(list) {
+		return nil, ErrUnknownUserList
+	}
+	return s.userMovieRepo.SetList(ctx, userID, movieID, list, nil)
+}
+
+// AnnotateUserMovies sets the in_watchlist, is_favorite and watched flags of
+// the movies for the user
+func (s *movieService) AnnotateUserMovies(ctx context.Context, userID uint, movies []models.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(movies))
+	for i := range movies {
+		ids[i] = movies[i].ID
+	}
+	states, err := s.userMovieRepo.FindByMovies(ctx, userID, ids)
+	if err != nil {
+		return err
+	}
+	byMovie := make(map[uint]*models.UserMovie, len(states))
+	for i := range states {
+		byMovie[states[i].MovieID] = &states[i]
+	}
+
+	for i := range movies {
+		var state models.UserMovie
+		if saved, ok := byMovie[movies[i].ID]; ok {
+			state = *saved
+		}
+		watched := state.WatchedAt != nil
+		movies[i].InWatchlist = &state.InWatchlist
+		movies[i].IsFavorite = &state.IsFavorite
+		movies[i].IsWatched = &watched
+		movies[i].WatchedAt = state.WatchedAt
+	}
+	return nil
+}
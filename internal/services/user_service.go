@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+
+	"movie-backend/internal/models"
+)
+
+func (s *authService) GetUsers(ctx context.Context, page, limit int) ([]models.User, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return s.repo.FindAll(ctx, page, limit)
+}
+
+// SetUserRole changes the role of a user. The new role is in the user's
+// access tokens from their next login or refresh.
+func (s *authService) SetUserRole(ctx context.Context, actorID, id uint, role string) (*models.User, error) {
+	if !models.ValidRole(role) {
+		return nil, ErrInvalidRole
+	}
+	if actorID == id {
+		return nil, ErrCannotChangeSelf
+	}
+
+	user, err := s.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateRole(ctx, id, role); err != nil {
+		return nil, err
+	}
+	user.Role = role
+	return user, nil
+}
+
+// DeleteUser deletes a user and signs them out. Access tokens they already
+// hold stay valid until they expire.
+func (s *authService) DeleteUser(ctx context.Context, actorID, id uint) error {
+	if actorID == id {
+		return ErrCannotChangeSelf
+	}
+	if _, err := s.GetUser(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
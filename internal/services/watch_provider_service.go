@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"movie-backend/internal/models"
+)
+
+// dashboardProviderLimit is the number of providers in the dashboard coverage
+// breakdown
+const dashboardProviderLimit = 10
+
+// GetMovieProviders returns where a movie can be watched, per region,
+// optionally only in one region
+func (s *movieService) GetMovieProviders(ctx context.Context, id uint, region string) ([]models.MovieRegionProviders, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	availability, err := s.providerRepo.FindByMovie(ctx, id, strings.ToUpper(region))
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows come ordered by region, so each region is one run of rows
+	regions := []models.MovieRegionProviders{}
+	for _, a := range availability {
+		if len(regions) == 0 || regions[len(regions)-1].Region != a.Region {
+			regions = append(regions, models.MovieRegionProviders{
+				Region:   a.Region,
+				Flatrate: []models.WatchProvider{},
+				Rent:     []models.WatchProvider{},
+				Buy:      []models.WatchProvider{},
+			})
+		}
+		current := &regions[len(regions)-1]
+		if current.Link == "" {
+			current.Link = a.Link
+		}
+		if a.Provider == nil {
+			continue
+		}
+		switch a.Type {
+		case models.MonetizationFlatrate:
+			current.Flatrate = append(current.Flatrate, *a.Provider)
+		case models.MonetizationRent:
+			current.Rent = append(current.Rent, *a.Provider)
+		case models.MonetizationBuy:
+			current.Buy = append(current.Buy, *a.Provider)
+		}
+	}
+	return regions, nil
+}
+
+// GetProviders returns every provider with its catalog coverage, optionally
+// in one region
+func (s *movieService) GetProviders(ctx context.Context, region string) ([]models.ProviderCoverage, error) {
+	return s.providerRepo.FindAllWithCoverage(ctx, strings.ToUpper(region), 0)
+}
+
+// watchProvidersFromTMDB converts where a movie can be watched in every
+// region. Only subscription, rental and purchase offers are kept.
+func watchProvidersFromTMDB(response *models.TMDBWatchProvidersResponse) []models.MovieWatchProvider {
+	var availability []models.MovieWatchProvider
+	seen := make(map[string]bool)
+	for region, offers := range response.Results {
+		for monetization, providers := range map[string][]models.TMDBWatchProvider{
+			models.MonetizationFlatrate: offers.Flatrate,
+			models.MonetizationRent:     offers.Rent,
+			models.MonetizationBuy:      offers.Buy,
+		} {
+			for _, p := range providers {
+				key := fmt.Sprintf("%s/%s/%d", region, monetization, p.ProviderID)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				availability = append(availability, models.MovieWatchProvider{
+					Region: strings.ToUpper(region),
+					Type:   monetization,
+					Link:   offers.Link,
+					Provider: &models.WatchProvider{
+						TMDBID:          p.ProviderID,
+						Name:            p.ProviderName,
+						LogoPath:        p.LogoPath,
+						DisplayPriority: p.DisplayPriority,
+					},
+				})
+			}
+		}
+	}
+	return availability
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// StrongETag builds a strong entity tag from an opaque value
+func StrongETag(value string) string {
+	return `"` + value + `"`
+}
+
+// WeakETag builds a weak entity tag from a version number, scoped to the
+// request URL and Accept-Language so different filters or locales of the same
+// endpoint never share a tag
+func WeakETag(c *fiber.Ctx, version int64) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(c.OriginalURL()))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(c.Get(fiber.HeaderAcceptLanguage)))
+	return fmt.Sprintf(`W/"%d-%x"`, version, h.Sum32())
+}
+
+// NotModified sets the ETag and Last-Modified validators on the response and
+// reports whether the request's If-None-Match / If-Modified-Since headers
+// show the client already has the current representation. If-None-Match uses
+// weak comparison and takes precedence over If-Modified-Since (RFC 7232).
+func NotModified(c *fiber.Ctx, etag string, lastModified time.Time) bool {
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	if etag != "" {
+		c.Set(fiber.HeaderETag, etag)
+	}
+	if !lastModified.IsZero() {
+		c.Set(fiber.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if ifNoneMatch := c.Get(fiber.HeaderIfNoneMatch); ifNoneMatch != "" {
+		if etag == "" {
+			return false
+		}
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || weakETagValue(candidate) == weakETagValue(etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince := c.Get(fiber.HeaderIfModifiedSince); ifModifiedSince != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+		// HTTP dates have second precision
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
+
+// NotModifiedResponse sends an empty 304 response
+func NotModifiedResponse(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNotModified)
+}
+
+func weakETagValue(etag string) string {
+	return strings.TrimPrefix(etag, "W/")
+}
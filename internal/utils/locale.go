@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/text/language"
+)
+
+// NormalizeLocale lowercases a BCP 47 tag and reports whether it is valid,
+// e.g. "pt_BR" becomes "pt-br"
+func NormalizeLocale(locale string) (string, bool) {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	if locale == "" {
+		return "", false
+	}
+	if _, err := language.Parse(locale); err != nil {
+		return "", false
+	}
+	return strings.ToLower(locale), true
+}
+
+// AcceptedLocales returns the locales of the Accept-Language header in order
+// of preference. A regional tag is followed by its base language, so "id-ID"
+// also matches translations stored as "id".
+func AcceptedLocales(c *fiber.Ctx) []string {
+	header := c.Get(fiber.HeaderAcceptLanguage)
+	if header == "" {
+		return nil
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags)*2)
+	locales := make([]string, 0, len(tags)*2)
+	add := func(locale string) {
+		if locale != "" && locale != "und" && !seen[locale] {
+			seen[locale] = true
+			locales = append(locales, locale)
+		}
+	}
+	for _, tag := range tags {
+		add(strings.ToLower(tag.String()))
+		base, _ := tag.Base()
+		add(base.String())
+	}
+	return locales
+}
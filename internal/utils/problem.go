@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// MIMEProblemJSON is the media type defined by RFC 7807
+	MIMEProblemJSON = "application/problem+json"
+
+	ErrorFormatStandard = "standard"
+	ErrorFormatProblem  = "problem"
+)
+
+var (
+	errorFormat        = ErrorFormatStandard
+	problemTypeBaseURL = ""
+)
+
+// ProblemDetails represents an RFC 7807 error response
+type ProblemDetails struct {
+	Type      string      `json:"type" example:"about:blank"`
+	Title     string      `json:"title" example:"Not Found"`
+	Status    int         `json:"status" example:"404"`
+	Detail    string      `json:"detail,omitempty" example:"Movie not found"`
+	Instance  string      `json:"instance,omitempty" example:"/api/v1/movies/42"`
+	RequestID string      `json:"request_id,omitempty" example:"3f1c2a6e-5b7d-4c8e-9f0a-1b2c3d4e5f60"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// ConfigureErrorFormat sets how error responses are rendered.
+// "problem" always emits RFC 7807 documents, anything else keeps the
+// StandardResponse envelope unless the client asks for problem+json.
+func ConfigureErrorFormat(format, typeBaseURL string) {
+	if strings.EqualFold(format, ErrorFormatProblem) {
+		errorFormat = ErrorFormatProblem
+	} else {
+		errorFormat = ErrorFormatStandard
+	}
+	problemTypeBaseURL = strings.TrimSuffix(typeBaseURL, "/")
+}
+
+// WantsProblemDetails reports whether the error for this request should be
+// rendered as application/problem+json. When the format is negotiated it also
+// adds Accept to Vary, so caches don't serve one format for the other.
+func WantsProblemDetails(c *fiber.Ctx) bool {
+	if errorFormat == ErrorFormatProblem {
+		return true
+	}
+	c.Vary(fiber.HeaderAccept)
+	return strings.Contains(strings.ToLower(c.Get(fiber.HeaderAccept)), MIMEProblemJSON)
+}
+
+// ProblemResponse sends an RFC 7807 problem details response
+func ProblemResponse(c *fiber.Ctx, code int, detail string, data interface{}) error {
+	problem := ProblemDetails{
+		Type:      problemType(code),
+		Title:     http.StatusText(code),
+		Status:    code,
+		Detail:    detail,
+		Instance:  c.OriginalURL(),
+		RequestID: RequestID(c),
+		Data:      data,
+	}
+	if problem.Title == "" {
+		problem.Title = "Error"
+	}
+	return c.Status(code).JSON(problem, MIMEProblemJSON)
+}
+
+// RequestID returns the request ID assigned by the requestid middleware
+func RequestID(c *fiber.Ctx) string {
+	if id, ok := c.Locals("requestid").(string); ok && id != "" {
+		return id
+	}
+	return c.GetRespHeader(fiber.HeaderXRequestID)
+}
+
+func problemType(code int) string {
+	if problemTypeBaseURL == "" {
+		return "about:blank"
+	}
+	return fmt.Sprintf("%s/%d", problemTypeBaseURL, code)
+}
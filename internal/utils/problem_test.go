@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestErrorResponsesVaryOnAccept(t *testing.T) {
+	defer ConfigureErrorFormat(ErrorFormatStandard, "")
+
+	tests := []struct {
+		name     string
+		format   string
+		accept   string
+		wantVary string
+		wantType string
+	}{
+		{"negotiated standard", ErrorFormatStandard, "", "Accept", fiber.MIMEApplicationJSON},
+		{"negotiated problem", ErrorFormatStandard, MIMEProblemJSON, "Accept", MIMEProblemJSON},
+		{"always problem", ErrorFormatProblem, "", "", MIMEProblemJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ConfigureErrorFormat(tt.format, "")
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				return ErrorResponse(c, fiber.StatusNotFound, "Movie not found")
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tt.accept != "" {
+				req.Header.Set(fiber.HeaderAccept, tt.accept)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := resp.Header.Get(fiber.HeaderVary); got != tt.wantVary {
+				t.Errorf("Vary = %q, want %q", got, tt.wantVary)
+			}
+			if got := resp.Header.Get(fiber.HeaderContentType); got != tt.wantType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantType)
+			}
+		})
+	}
+}
@@ -44,6 +44,9 @@ func SuccessWithMetaResponse(c *fiber.Ctx, code int, message string, data interf
 
 // ErrorResponse sends an error response
 func ErrorResponse(c *fiber.Ctx, code int, message string) error {
+	if WantsProblemDetails(c) {
+		return ProblemResponse(c, code, message, nil)
+	}
 	status := "error"
 	if code >= 500 {
 		status = "fail"
@@ -57,6 +60,9 @@ func ErrorResponse(c *fiber.Ctx, code int, message string) error {
 
 // ErrorWithDataResponse sends an error response with additional data
 func ErrorWithDataResponse(c *fiber.Ctx, code int, message string, data interface{}) error {
+	if WantsProblemDetails(c) {
+		return ProblemResponse(c, code, message, data)
+	}
 	status := "error"
 	if code >= 500 {
 		status = "fail"